@@ -0,0 +1,186 @@
+package startup
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+
+	"github.com/KusoKaihatsuSha/startup/internal/helpers"
+	"github.com/KusoKaihatsuSha/startup/internal/order"
+	tags "github.com/KusoKaihatsuSha/startup/internal/tag"
+)
+
+// ErrNoCommand - the subcommand of the struct is not selected on the command line
+var ErrNoCommand = errors.New("startup: command is not selected")
+
+// command - subcommand with own struct and stages
+type command struct {
+	name   string
+	help   string
+	typ    reflect.Type
+	stages []order.Stages
+}
+
+var (
+	commandsMu sync.RWMutex
+	commands   = make(map[string]command)
+)
+
+/*
+AddCommand binds the subcommand name to the struct T with own stages.
+Options of the subcommand follow its name on the command line.
+Options of the parent (root struct of Get/Load) are allowed before and after the name of the subcommand.
+The name of the subcommand is the first argument which is not the flag or the value of the flag.
+Options of the parent are inherited: the field of T with the same flag (embedded struct of the parent) gets the value.
+
+Example:
+
+	startup.AddCommand[Serve]("serve", "Run server", startup.ENV, startup.FLAG)
+	startup.AddCommand[Migrate]("migrate", "Migrate database", startup.FLAG)
+
+	// app -v serve -port 8080
+	global := startup.Get[Global](startup.ENV, startup.FLAG)
+	switch startup.Command() {
+	case "serve":
+		serve, err := startup.LoadCommand[Serve]()
+		...
+	}
+
+Flag '-h' after the name of the subcommand prints only flags of the subcommand.
+*/
+func AddCommand[T any](name, help string, stages ...order.Stages) {
+	commandsMu.Lock()
+	defer commandsMu.Unlock()
+	commands[name] = command{
+		name:   name,
+		help:   help,
+		typ:    reflect.TypeOf((*T)(nil)).Elem(),
+		stages: stages,
+	}
+}
+
+// Command returns the name of the subcommand selected on the command line or "" if not any.
+// Flags of the parent are known after Get or Load of the root struct.
+func Command() string {
+	_, name := commandIndex(nil)
+	return name
+}
+
+// LoadCommand works as Load for the struct T of the selected subcommand.
+// Return ErrNoCommand if the subcommand of T is not selected and flag.ErrHelp after the help printing.
+func LoadCommand[T any]() (T, error) {
+	load, err := loadCommand[T]()
+	if err != nil {
+		return *new(T), err
+	}
+	return load.CustomerConfiguration, load.err()
+}
+
+// GetCommand works as GetForce for the struct T of the selected subcommand.
+// Exit after the help printing, same as flag.Parse.
+func GetCommand[T any]() T {
+	load, err := loadCommand[T]()
+	if err != nil {
+		return *new(T)
+	}
+	if errors.Is(load.err(), flag.ErrHelp) {
+		os.Exit(0)
+	}
+	return required(load).CustomerConfiguration
+}
+
+// loadCommand - loading of the struct T of the selected subcommand. ErrNoCommand if the subcommand of T is not selected
+func loadCommand[T any]() (temp[T], error) {
+	i, name := commandIndex(nil)
+	commandsMu.RLock()
+	cmd, ok := commands[name]
+	commandsMu.RUnlock()
+	if !ok || cmd.typ != reflect.TypeOf((*T)(nil)).Elem() {
+		return temp[T]{}, ErrNoCommand
+	}
+
+	// ---debug---
+	if DEBUG {
+		fmt.Printf("COMMAND => %s\n", name)
+		helpers.PrintDebug(cmd.stages...)
+	}
+	// ---debug---
+
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+	// flags of the parent before the name are inherited
+	input := append(append([]string(nil), os.Args[1:i+1]...), os.Args[i+2:]...)
+	return getWith[T](input, flagSet, cmd.stages...), nil
+}
+
+/*
+commandIndex - index of the subcommand in os.Args[1:] and the name. -1 if not any.
+The subcommand is the first argument which is not the flag: the values of the flags of the tags t,
+the config file flag and the flags of flag.CommandLine are skipped ('-name serve')
+*/
+func commandIndex(t tags.Tags) (int, string) {
+	commandsMu.RLock()
+	defer commandsMu.RUnlock()
+	if len(commands) == 0 {
+		return -1, ""
+	}
+	args := os.Args[1:]
+	for k := 0; k < len(args); k++ {
+		arg := args[k]
+		if arg == "--" {
+			break
+		}
+		if len(arg) > 1 && arg[0] == '-' {
+			name := strings.TrimPrefix(arg[1:], "-")
+			if !strings.Contains(name, "=") && (name == ConfigFlag || tags.NeedsValue(name, t, flag.CommandLine)) {
+				k++
+			}
+			continue
+		}
+		if _, ok := commands[arg]; ok {
+			return k, arg
+		}
+		// positional argument of the parent
+		break
+	}
+	return -1, ""
+}
+
+// rootArgs - command line arguments without the program name and the name of the subcommand.
+// T is the root struct: values of its flags are not the subcommand
+func rootArgs[T any](stages ...order.Stages) []string {
+	i, _ := commandIndex(tags.Struct[T](stages...))
+	if i < 0 {
+		return os.Args[1:]
+	}
+	args := append([]string(nil), os.Args[1:i+1]...)
+	return append(args, os.Args[i+2:]...)
+}
+
+// printCommands - printing the list of the subcommands for the help
+func printCommands(f *flag.FlagSet) {
+	commandsMu.RLock()
+	defer commandsMu.RUnlock()
+	if len(commands) == 0 || f != flag.CommandLine {
+		return
+	}
+	red := color.New(color.FgRed).SprintfFunc()
+	cyan := color.New(color.FgCyan).SprintfFunc()
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	_, err := fmt.Fprint(f.Output(), "Commands:\n")
+	helpers.ToLog(err, "print data error")
+	for _, name := range names {
+		_, err = fmt.Fprintf(f.Output(), "  %s\n    \t%s\n", red("%s", name), cyan("%s", commands[name].help))
+		helpers.ToLog(err, "print data error")
+	}
+}
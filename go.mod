@@ -3,9 +3,11 @@ module github.com/KusoKaihatsuSha/startup
 go 1.21
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/fatih/color v1.16.0
 	github.com/google/uuid v1.6.0
 	github.com/rs/zerolog v1.32.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
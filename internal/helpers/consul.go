@@ -0,0 +1,75 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Consul - request of the keys under the prefix from the Consul-style KV HTTP API
+type Consul struct {
+	Address string
+	// Token - ACL token. Empty - without authorization
+	Token   string
+	Timeout time.Duration
+	Client  *http.Client
+}
+
+// consulPair - one key of the response of '/v1/kv/<prefix>?recurse=true'. Value is base64 (nil for the folder)
+type consulPair struct {
+	Key   string
+	Value []byte
+}
+
+// FetchConsul return the values of the keys under the prefix. Keys are without the prefix. Nil if the prefix is not exist
+func FetchConsul(c Consul, prefix string) (map[string]string, error) {
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	ctx := context.Background()
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+	prefix = strings.TrimPrefix(prefix, "/")
+	address := strings.TrimSuffix(c.Address, "/") + "/v1/kv/" + (&url.URL{Path: prefix}).EscapedPath()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address+"?recurse=true", nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.Token != "" {
+		req.Header.Set("X-Consul-Token", c.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		ToLog(resp.Body.Close(), "response body close error")
+	}()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("kv '%s': status %s", address, resp.Status)
+	}
+	var pairs []consulPair
+	if err := json.NewDecoder(resp.Body).Decode(&pairs); err != nil {
+		return nil, fmt.Errorf("kv '%s' parse error: %w", address, err)
+	}
+	ret := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		if p.Value == nil {
+			continue
+		}
+		ret[strings.TrimPrefix(p.Key, prefix)] = string(p.Value)
+	}
+	return ret, nil
+}
@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+/*
+ReadDir return the content of every file of the directory by the filename or error of the reading.
+Nil if the directory is not exist.
+Hidden files and the Kubernetes layout ('..data', '..2024_01_01_00_00_00.000000000') are skipped,
+the files of the ConfigMap are the symlinks to '..data/<key>' and are reading through it.
+Line break at the end of the content is trimmed
+*/
+func ReadDir(fsys fs.FS, dir string) (map[string]string, error) {
+	dir = separatorCorrect(dir)
+	entries, err := readDir(fsys, dir)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("directory '%s' is not access: %w", dir, err)
+	}
+	ret := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		// symlinks are following
+		info, err := stat(fsys, path)
+		if err != nil {
+			return nil, fmt.Errorf("file '%s' is not access: %w", path, err)
+		}
+		if info.IsDir() {
+			continue
+		}
+		data, err := ReadFile(fsys, path)
+		if err != nil {
+			return nil, fmt.Errorf("file '%s' is not access: %w", path, err)
+		}
+		ret[name] = strings.TrimRight(string(data), "\r\n")
+	}
+	return ret, nil
+}
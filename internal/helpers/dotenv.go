@@ -0,0 +1,144 @@
+package helpers
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// ReadDotEnv return the variables of the dotenv file or error of the reading/parsing. Nil if the file is not exist
+func ReadDotEnv(fsys fs.FS, filename string) (map[string]string, error) {
+	filename = separatorCorrect(filename)
+	if !Exist(fsys, filename) {
+		return nil, nil
+	}
+	f, err := ReadFile(fsys, filename)
+	if err != nil {
+		return nil, fmt.Errorf("dotenv file '%s' is not access: %w", filename, err)
+	}
+	ret, err := parseDotEnv(string(f))
+	if err != nil {
+		return nil, fmt.Errorf("dotenv file '%s' parse error: %w", filename, err)
+	}
+	return ret, nil
+}
+
+/*
+parseDotEnv - parse the 'KEY=VALUE' lines.
+Supported:
+  - comments: lines starting with '#' and ' #' after the unquoted value
+  - 'export KEY=VALUE'
+  - "double" quoted values with escapes (\\, \", \n, \r, \t, \$) on the several lines
+  - 'single' quoted values as is
+  - ${VAR}, $VAR and ${VAR:-default} in the unquoted and the double quoted values.
+    Variables of the file above are using first, then the environment
+*/
+func parseDotEnv(data string) (map[string]string, error) {
+	ret := make(map[string]string)
+	lookup := func(name string) (string, bool) {
+		if v, ok := ret[name]; ok {
+			return v, true
+		}
+		return os.LookupEnv(name)
+	}
+	data = strings.TrimPrefix(strings.ReplaceAll(data, "\r\n", "\n"), "\ufeff")
+	line := 1
+	for len(data) > 0 {
+		var text string
+		text, data, _ = strings.Cut(data, "\n")
+		start := line
+		line++
+		text = strings.TrimSpace(text)
+		if text == "" || text[0] == '#' {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(text, "export"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			text = strings.TrimSpace(rest)
+		}
+		k := strings.Index(text, "=")
+		if k <= 0 {
+			return nil, fmt.Errorf("line %d: key without value '%s'", start, text)
+		}
+		key, value := strings.TrimSpace(text[:k]), strings.TrimSpace(text[k+1:])
+		switch {
+		case strings.HasPrefix(value, `"`):
+			// value on the several lines
+			for closingQuote(value) < 0 && len(data) > 0 {
+				var next string
+				next, data, _ = strings.Cut(data, "\n")
+				value += "\n" + next
+				line++
+			}
+			end := closingQuote(value)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unclosed quote in '%s'", start, key)
+			}
+			if err := afterQuote(value[end+1:], "#"); err != nil {
+				return nil, fmt.Errorf("line %d: %w", start, err)
+			}
+			unquoted, err := unquoteDotEnv(value[1:end], lookup)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", start, err)
+			}
+			value = unquoted
+		case strings.HasPrefix(value, "'"):
+			end := strings.Index(value[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unclosed quote in '%s'", start, key)
+			}
+			if err := afterQuote(value[end+2:], "#"); err != nil {
+				return nil, fmt.Errorf("line %d: %w", start, err)
+			}
+			value = value[1 : end+1]
+		default:
+			value = expandDotEnv(stripComment(value), lookup)
+		}
+		ret[key] = value
+	}
+	return ret, nil
+}
+
+// unquoteDotEnv - content of the double quoted value with the escapes and the variables.
+// '\$' is the dollar sign without the variable
+func unquoteDotEnv(v string, lookup func(string) (string, bool)) (string, error) {
+	var b, part strings.Builder
+	for i := 0; i < len(v); i++ {
+		if v[i] != '\\' {
+			part.WriteByte(v[i])
+			continue
+		}
+		if i++; i == len(v) {
+			return "", fmt.Errorf("bad escape at the end of '%s'", v)
+		}
+		switch v[i] {
+		case '\\', '"':
+			part.WriteByte(v[i])
+		case 'n':
+			part.WriteByte('\n')
+		case 'r':
+			part.WriteByte('\r')
+		case 't':
+			part.WriteByte('\t')
+		case '$':
+			b.WriteString(expandDotEnv(part.String(), lookup))
+			part.Reset()
+			b.WriteByte('$')
+		default:
+			return "", fmt.Errorf("bad escape '\\%c' in '%s'", v[i], v)
+		}
+	}
+	b.WriteString(expandDotEnv(part.String(), lookup))
+	return b.String(), nil
+}
+
+// expandDotEnv - replace ${VAR}, $VAR and ${VAR:-default} with the values
+func expandDotEnv(v string, lookup func(string) (string, bool)) string {
+	return os.Expand(v, func(name string) string {
+		name, def, hasDef := strings.Cut(name, ":-")
+		if value, ok := lookup(name); ok && (value != "" || !hasDef) {
+			return value
+		}
+		return def
+	})
+}
@@ -0,0 +1,116 @@
+package helpers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	t.Setenv("DOTENV_TEST_HOST", "env-host")
+	tests := []struct {
+		name string
+		data string
+		want map[string]string
+		// err - part of the error
+		err string
+	}{
+		{
+			name: "values and comments",
+			data: "# comment\nA=1\nexport B = two # tail\nC=http://host/#anchor\n\nD=",
+			want: map[string]string{"A": "1", "B": "two", "C": "http://host/#anchor", "D": ""},
+		},
+		{
+			name: "comments inside quotes",
+			data: "A=\"x # y\" # tail\nB='x # y'",
+			want: map[string]string{"A": "x # y", "B": "x # y"},
+		},
+		{
+			name: "escapes",
+			data: `A="tab\t\"q\""` + "\n" + `B='no\t$A'`,
+			want: map[string]string{"A": "tab\t\"q\"", "B": `no\t$A`},
+		},
+		{
+			name: "escaped dollar sign",
+			data: `A=a` + "\n" + `B="x\$y ${A}\\$A \${A}"`,
+			want: map[string]string{"A": "a", "B": `x$y a\a ${A}`},
+		},
+		{
+			name: "export with tab",
+			data: "export\tA=1\nexported=2",
+			want: map[string]string{"A": "1", "exported": "2"},
+		},
+		{
+			name: "several lines",
+			data: "A=\"first\nsecond\"\nB=1",
+			want: map[string]string{"A": "first\nsecond", "B": "1"},
+		},
+		{
+			name: "expansion",
+			data: "A=a\nB=${A}-$A\nC=\"${DOTENV_TEST_HOST}\"\nD=${DOTENV_TEST_MISSING:-def}",
+			want: map[string]string{"A": "a", "B": "a-a", "C": "env-host", "D": "def"},
+		},
+		{
+			name: "windows line breaks and byte order mark",
+			data: "\ufeffA=1\r\nB=2\r\n",
+			want: map[string]string{"A": "1", "B": "2"},
+		},
+		{
+			name: "unterminated double quote",
+			data: "A=1\nB=\"value\nC=3",
+			err:  "line 2: unclosed quote in 'B'",
+		},
+		{
+			name: "escaped closing quote",
+			data: `A="value\"`,
+			err:  "line 1: unclosed quote in 'A'",
+		},
+		{
+			name: "unterminated single quote",
+			data: "A='value",
+			err:  "line 1: unclosed quote in 'A'",
+		},
+		{
+			name: "data after quoted value",
+			data: `A="x"y`,
+			err:  "line 1: unexpected 'y' after the quoted value",
+		},
+		{
+			name: "bad escape",
+			data: "A=1\nB=\"\\q\"",
+			err:  `line 2: bad escape '\q' in '\q'`,
+		},
+		{
+			name: "escape of Go",
+			data: `A="\x41"`,
+			err:  `line 1: bad escape '\x' in '\x41'`,
+		},
+		{
+			name: "key without value",
+			data: "A=1\nB",
+			err:  "line 2: key without value 'B'",
+		},
+		{
+			name: "line of the error after several lines",
+			data: "A=\"x\ny\"\nB",
+			err:  "line 3: key without value 'B'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDotEnv(tt.data)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package helpers
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// OS - file system of the operating system. Names are the paths of the OS (absolute too)
+var OS fs.FS = osFS{}
+
+// osFS - fs.FS over the os package
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+func (osFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+// Stat - symlinks are following
+func (osFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// Glob - patterns with the separator of the OS
+func (osFS) Glob(pattern string) ([]string, error) {
+	return filepath.Glob(pattern)
+}
+
+// fsName - name of the file inside the file system. Slash separated for the other file systems
+func fsName(fsys fs.FS, name string) string {
+	if _, ok := fsys.(osFS); ok {
+		return name
+	}
+	return filepath.ToSlash(name)
+}
+
+// ReadFile return the content of the file from the file system
+func ReadFile(fsys fs.FS, name string) ([]byte, error) {
+	return fs.ReadFile(fsys, fsName(fsys, name))
+}
+
+// stat return the info of the file from the file system
+func stat(fsys fs.FS, name string) (fs.FileInfo, error) {
+	return fs.Stat(fsys, fsName(fsys, name))
+}
+
+// readDir return the entries of the directory from the file system in lexical order
+func readDir(fsys fs.FS, name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(fsys, fsName(fsys, name))
+}
+
+// glob return the names of the files from the file system matching the pattern in lexical order
+func glob(fsys fs.FS, pattern string) ([]string, error) {
+	return fs.Glob(fsys, fsName(fsys, pattern))
+}
+
+// Exist - file or directory exist in the file system
+func Exist(fsys fs.FS, name string) bool {
+	if name == "." || name == "" {
+		return false
+	}
+	_, err := stat(fsys, name)
+	return err == nil
+}
@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestValidConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.json", "b.yaml", "c.txt", ".hidden.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mapFS := fstest.MapFS{
+		"conf.d/a.json":       {Data: []byte("{}")},
+		"conf.d/b.toml":       {Data: []byte("")},
+		"conf.d/c.txt":        {Data: []byte("")},
+		"conf.d/sub/d.json":   {Data: []byte("{}")},
+		"conf.d/.hidden.json": {Data: []byte("{}")},
+	}
+	tests := []struct {
+		name  string
+		fsys  fs.FS
+		files []string
+		want  []string
+	}{
+		{
+			name:  "os glob",
+			fsys:  OS,
+			files: []string{filepath.Join(dir, "*.json")},
+			want:  []string{filepath.Join(dir, ".hidden.json"), filepath.Join(dir, "a.json")},
+		},
+		{
+			name:  "os directory",
+			fsys:  OS,
+			files: []string{dir},
+			want:  []string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.yaml")},
+		},
+		{
+			name:  "os missing file and stdin",
+			fsys:  OS,
+			files: []string{filepath.Join(dir, "missing.json"), Stdin},
+			want:  []string{Stdin},
+		},
+		{
+			name:  "map glob with backslashes",
+			fsys:  mapFS,
+			files: []string{`conf.d\*.json`},
+			want:  []string{"conf.d/.hidden.json", "conf.d/a.json"},
+		},
+		{
+			name:  "map directory",
+			fsys:  mapFS,
+			files: []string{"conf.d"},
+			want:  []string{"conf.d/a.json", "conf.d/b.toml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidConfigFiles(tt.fsys, tt.files)
+			for k := range got {
+				got[k] = filepath.ToSlash(got[k])
+			}
+			want := append([]string(nil), tt.want...)
+			for k := range want {
+				want[k] = filepath.ToSlash(want[k])
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -3,19 +3,24 @@
 package helpers
 
 import (
-	"encoding/json"
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
 
 	"github.com/KusoKaihatsuSha/startup/internal/order"
 )
@@ -170,16 +175,81 @@ func CloseFile(file *os.File) {
 	}
 }
 
+// Formats of the setting file
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatINI  = "ini"
+	FormatTOML = "toml"
+)
+
+// FileFormat return format of the setting file by the extension. JSON if the extension is unknown
+func FileFormat(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".ini":
+		return FormatINI
+	case ".toml":
+		return FormatTOML
+	default:
+		return FormatJSON
+	}
+}
+
 // SettingsFile return map[string]string from the setting file
 func SettingsFile(filename string) (compare map[string]any) {
+	compare, err := ReadSettingsFile(OS, filename, FileFormat(filename))
+	ToLog(err, fmt.Sprintf("settings file '%s' error", filename))
+	return
+}
+
+// ReadSettingsFile return map[string]any from the setting file of the file system in the format or error of the reading/parsing
+func ReadSettingsFile(fsys fs.FS, filename, format string) (compare map[string]any, err error) {
 	filename = separatorCorrect(filename)
-	if FileExist(filename) {
-		f, err := os.ReadFile(filename)
-		ToLog(err, fmt.Sprintf("settings file '%s' is not access", filename))
-		err = json.Unmarshal(f, &compare)
-		ToLog(err, fmt.Sprintf("settings file '%s' parse error", filename))
+	if !Exist(fsys, filename) {
+		return nil, nil
 	}
-	return
+	f, err := ReadFile(fsys, filename)
+	if err != nil {
+		return nil, fmt.Errorf("settings file '%s' is not access: %w", filename, err)
+	}
+	compare, err = DecodeSettings(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("settings file '%s' parse error: %w", filename, err)
+	}
+	return compare, nil
+}
+
+// DecodeSettings return map[string]any from the settings in the format
+func DecodeSettings(f []byte, format string) (compare map[string]any, err error) {
+	switch format {
+	case FormatYAML:
+		err = yaml.Unmarshal(f, &compare)
+	case FormatTOML:
+		err = toml.Unmarshal(f, &compare)
+	case FormatINI:
+		if !bytes.HasPrefix(bytes.TrimSpace(f), []byte("{")) {
+			compare, err = parseINI(f)
+			break
+		}
+		// JSON inside the '.ini' file. Old configs
+		fallthrough
+	default:
+		err = decodeJSONC(f, &compare)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return compare, nil
+}
+
+// ContentFormat return format of the settings by the content: JSON if it starts with '{', YAML for other
+func ContentFormat(f []byte) string {
+	if bytes.HasPrefix(bytes.TrimSpace(f), []byte("{")) {
+		return FormatJSON
+	}
+	return FormatYAML
 }
 
 // CreateFile create file or temp file
@@ -218,16 +288,68 @@ func ValidTempFile(filename string) string {
 
 // ValidConfigFile - validation type.
 // create same name file in Temp folder or create random temp file
-func ValidConfigFile(filename string) string {
+func ValidConfigFile(fsys fs.FS, filename string) string {
 	filename = separatorCorrect(filename)
 
-	if !FileExist(filename) || filename == "" || filename == "." {
+	if !Exist(fsys, filename) {
 		return ""
 	}
 
 	return filename
 }
 
+/*
+ValidConfigFiles - validation on the list of the config files. Order is saving:
+  - glob pattern ("conf.d/*.json") is the matched files in lexical order
+  - directory ("conf.d") is the files of the known formats (.json, .yaml, .yml, .ini, .toml) in lexical order
+  - not exist files are skipped
+  - "-" is the standard input
+*/
+func ValidConfigFiles(fsys fs.FS, filenames []string) []string {
+	var ret []string
+	for _, filename := range filenames {
+		var matches []string
+		if filename == Stdin {
+			ret = append(ret, filename)
+			continue
+		}
+		if strings.ContainsAny(filename, "*?[") {
+			matches, _ = glob(fsys, separatorCorrect(filename))
+		} else if filename = ValidConfigFile(fsys, filename); filename != "" {
+			matches = []string{filename}
+		}
+		for _, v := range matches {
+			info, err := stat(fsys, v)
+			switch {
+			case err != nil:
+			case info.IsDir():
+				ret = append(ret, configDirFiles(fsys, v)...)
+			default:
+				ret = append(ret, v)
+			}
+		}
+	}
+	return ret
+}
+
+// configDirFiles - files of the known formats inside the directory in lexical order
+func configDirFiles(fsys fs.FS, dir string) []string {
+	entries, err := readDir(fsys, dir)
+	ToLog(err, fmt.Sprintf("directory '%s' error", dir))
+	var ret []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".json", ".yaml", ".yml", ".ini", ".toml":
+			ret = append(ret, filepath.Join(dir, name))
+		}
+	}
+	return ret
+}
+
 // ValidFile - validation type.
 // Create file in not exist.
 func ValidFile(filename string) string {
@@ -245,16 +367,24 @@ func ValidFile(filename string) string {
 
 // ValidDuration - validation on the duration
 func ValidDuration(v string) time.Duration {
+	tmp, err := ParseDuration(v)
+	ToLog(err, fmt.Sprintf("duration '%s' parse error", v))
+	return tmp
+}
+
+// ParseDuration - parse the duration. Number without unit is seconds
+func ParseDuration(v string) (time.Duration, error) {
+	if v == "" {
+		return 0, errors.New("empty duration")
+	}
 	if v[0] == '-' || v[0] == '+' {
 		v = v[1:]
 	}
 	l := len(v) - 1
-	if '0' <= v[l] && v[l] <= '9' {
+	if l >= 0 && '0' <= v[l] && v[l] <= '9' {
 		v += defaultTimePostfix
 	}
-	tmp, err := time.ParseDuration(v)
-	ToLog(err, fmt.Sprintf("duration '%s' parse error", v))
-	return tmp
+	return time.ParseDuration(v)
 }
 
 // ValidURL - validation on the url
@@ -329,9 +459,19 @@ func printDebug(stages ...order.Stages) {
 		case order.FLAG:
 			fmt.Printf("%s%s%s", prefix, "[Flags]", suf)
 		case order.FILE:
-			fmt.Printf("%s%s%s", prefix, "[JSON File]", suf)
+			fmt.Printf("%s%s%s", prefix, "[Config File]", suf)
 		case order.ENV:
 			fmt.Printf("%s%s%s", prefix, "[Environments]", suf)
+		case order.DOTENV:
+			fmt.Printf("%s%s%s", prefix, "[Dotenv File]", suf)
+		case order.DIR:
+			fmt.Printf("%s%s%s", prefix, "[Directory]", suf)
+		case order.INLINE:
+			fmt.Printf("%s%s%s", prefix, "[Inline Config]", suf)
+		case order.REMOTE:
+			fmt.Printf("%s%s%s", prefix, "[Remote Config]", suf)
+		case order.KV:
+			fmt.Printf("%s%s%s", prefix, "[Key/Value]", suf)
 		}
 	}
 }
@@ -366,20 +506,40 @@ func PrintDebug(stages ...order.Stages) {
 
 }
 
+// PresetPreload - stages of the preload of the filepath of the config file.
+// NoPreloadConfig - ordered stages without the directory and the network stages (DIR, REMOTE, KV):
+// they are running once, in the loading
 func PresetPreload(stages ...order.Stages) []order.Stages {
 	for _, v := range stages {
 		switch v {
 		case order.NoPreloadConfig:
-			return stages
+			return slices.DeleteFunc(slices.Clone(stages), func(v order.Stages) bool {
+				return v == order.DIR || v == order.REMOTE || v == order.KV
+			})
 		case order.PreloadConfigEnvThenFlag:
-			return []order.Stages{order.ENV, order.FLAG}
+			return withDotEnv(stages, order.ENV, order.FLAG)
 		case order.PreloadConfigFlagThenEnv:
-			return []order.Stages{order.FLAG, order.ENV}
+			return withDotEnv(stages, order.FLAG, order.ENV)
 		case order.PreloadConfigFlag:
 			return []order.Stages{order.FLAG}
 		case order.PreloadConfigEnv:
-			return []order.Stages{order.ENV}
+			return withDotEnv(stages, order.ENV)
+		}
+	}
+	return withDotEnv(stages, order.ENV, order.FLAG)
+}
+
+// withDotEnv - preload stages. Dotenv file is before the environment if DOTENV exist in stages
+func withDotEnv(stages []order.Stages, preload ...order.Stages) []order.Stages {
+	if !slices.Contains(stages, order.DOTENV) {
+		return preload
+	}
+	ret := make([]order.Stages, 0, len(preload)+1)
+	for _, v := range preload {
+		if v == order.ENV {
+			ret = append(ret, order.DOTENV)
 		}
+		ret = append(ret, v)
 	}
-	return []order.Stages{order.ENV, order.FLAG}
+	return ret
 }
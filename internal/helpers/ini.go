@@ -0,0 +1,141 @@
+package helpers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+parseINI - parse the INI data to the nested maps.
+Supported:
+  - comments: lines starting with ';' or '#', and ' ;' / ' #' after the unquoted value
+  - sections: '[db]' and '[db.replica]' are nested maps
+  - dotted keys: 'db.host = localhost' is the same as 'host' inside '[db]'
+  - separators '=' and ':'
+  - quoted values: "double" with escapes ("\t", "\"") and 'single' as is
+
+All values are strings. The types of the fields are parsing them same as the flag values
+*/
+func parseINI(data []byte) (map[string]any, error) {
+	ret := make(map[string]any)
+	section := ret
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		switch {
+		case text == "", text[0] == ';', text[0] == '#':
+			continue
+		case text[0] == '[':
+			name, ok := strings.CutSuffix(stripComment(text), "]")
+			name = strings.TrimSpace(name[1:])
+			if !ok || name == "" {
+				return nil, fmt.Errorf("line %d: wrong section '%s'", line, text)
+			}
+			var err error
+			if section, err = iniSection(ret, strings.Split(name, ".")); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			continue
+		}
+		k := strings.IndexAny(text, "=:")
+		if k <= 0 {
+			return nil, fmt.Errorf("line %d: key without value '%s'", line, text)
+		}
+		key, value := strings.TrimSpace(text[:k]), strings.TrimSpace(text[k+1:])
+		value, err := iniValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		path := strings.Split(key, ".")
+		m, err := iniSection(section, path[:len(path)-1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		m[path[len(path)-1]] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// iniSection - nested map by the path. Maps are creating if not exist
+func iniSection(m map[string]any, path []string) (map[string]any, error) {
+	for _, name := range path {
+		name = strings.TrimSpace(name)
+		switch v := m[name].(type) {
+		case nil:
+			next := make(map[string]any)
+			m[name] = next
+			m = next
+		case map[string]any:
+			m = v
+		default:
+			return nil, fmt.Errorf("key '%s' is the value and the section", name)
+		}
+	}
+	return m, nil
+}
+
+// iniValue - unquoted value without the comment
+func iniValue(v string) (string, error) {
+	switch {
+	case strings.HasPrefix(v, `"`):
+		end := closingQuote(v)
+		if end < 0 {
+			return "", fmt.Errorf("unclosed quote in '%s'", v)
+		}
+		if err := afterQuote(v[end+1:], ";#"); err != nil {
+			return "", err
+		}
+		return strconv.Unquote(v[:end+1])
+	case strings.HasPrefix(v, "'"):
+		end := strings.Index(v[1:], "'")
+		if end < 0 {
+			return "", fmt.Errorf("unclosed quote in '%s'", v)
+		}
+		if err := afterQuote(v[end+2:], ";#"); err != nil {
+			return "", err
+		}
+		return v[1 : end+1], nil
+	}
+	return stripComment(v), nil
+}
+
+// afterQuote - only the comment is allowed after the closing quote
+func afterQuote(rest, comments string) error {
+	rest = strings.TrimLeft(rest, " \t")
+	if rest == "" || strings.IndexByte(comments, rest[0]) >= 0 {
+		return nil
+	}
+	return fmt.Errorf("unexpected '%s' after the quoted value", rest)
+}
+
+// closingQuote - index of the closing double quote. -1 if not exist
+func closingQuote(v string) int {
+	for i := 1; i < len(v); i++ {
+		switch v[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// stripComment - value without the comment after the space
+func stripComment(v string) string {
+	for i := 1; i < len(v); i++ {
+		if (v[i] == ';' || v[i] == '#') && (v[i-1] == ' ' || v[i-1] == '\t') {
+			return strings.TrimSpace(v[:i])
+		}
+	}
+	return v
+}
@@ -0,0 +1,122 @@
+package helpers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseINI(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want map[string]any
+		// err - part of the error
+		err string
+	}{
+		{
+			name: "sections, dotted keys and separators",
+			data: "name = app\n[db]\nhost: localhost\nreplica.port = 5433\n[db.cache]\nttl = 1m",
+			want: map[string]any{
+				"name": "app",
+				"db": map[string]any{
+					"host":    "localhost",
+					"replica": map[string]any{"port": "5433"},
+					"cache":   map[string]any{"ttl": "1m"},
+				},
+			},
+		},
+		{
+			name: "comments",
+			data: "; first\n# second\n[db] ; section\nhost = localhost # tail\nurl = http://host/#anchor",
+			want: map[string]any{"db": map[string]any{"host": "localhost", "url": "http://host/#anchor"}},
+		},
+		{
+			name: "comments inside quotes",
+			data: "a = \"x ; y # z\" ; tail\nb = 'x ; y' # tail",
+			want: map[string]any{"a": "x ; y # z", "b": "x ; y"},
+		},
+		{
+			name: "escapes",
+			data: `a = "tab\there \"quoted\""` + "\n" + `b = 'no\tescape'`,
+			want: map[string]any{"a": "tab\there \"quoted\"", "b": `no\tescape`},
+		},
+		{
+			name: "byte order mark and empty value",
+			data: "\ufeffa =\nb = \"\"",
+			want: map[string]any{"a": "", "b": ""},
+		},
+		{
+			name: "unterminated section",
+			data: "a = 1\n[db\nhost = localhost",
+			err:  "line 2: wrong section '[db'",
+		},
+		{
+			name: "empty section",
+			data: "[ ]",
+			err:  "line 1: wrong section '[ ]'",
+		},
+		{
+			name: "unterminated double quote",
+			data: "a = \"value\nb = 1",
+			err:  `line 1: unclosed quote in '"value'`,
+		},
+		{
+			name: "escaped closing quote",
+			data: `a = "value\"`,
+			err:  `line 1: unclosed quote in '"value\"'`,
+		},
+		{
+			name: "unterminated single quote",
+			data: "a = 'value",
+			err:  `line 1: unclosed quote in ''value'`,
+		},
+		{
+			name: "data after quoted value",
+			data: "a = \"x\" y",
+			err:  "line 1: unexpected 'y' after the quoted value",
+		},
+		{
+			name: "data after single quoted value",
+			data: "a = 'x'y ; tail",
+			err:  "line 1: unexpected 'y ; tail' after the quoted value",
+		},
+		{
+			name: "bad escape",
+			data: "a = 1\nb = \"\\q\"",
+			err:  "line 2: invalid syntax",
+		},
+		{
+			name: "key without value",
+			data: "[db]\nhost",
+			err:  "line 2: key without value 'host'",
+		},
+		{
+			name: "empty key",
+			data: "= value",
+			err:  "line 1: key without value '= value'",
+		},
+		{
+			name: "value and section",
+			data: "db = 1\n[db]\nhost = localhost",
+			err:  "line 2: key 'db' is the value and the section",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseINI([]byte(tt.data))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
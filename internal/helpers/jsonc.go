@@ -0,0 +1,115 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// stripJSONC - JSON with comments ('//' and '/* */') and trailing commas to the plain JSON.
+// Comments and commas are replacing with the spaces, line and column of the data are not changing
+func stripJSONC(data []byte) []byte {
+	ret := bytes.Clone(data)
+	// comma - index of the last comma which can be trailing. -1 if not exist
+	comma := -1
+	// prev - last byte of the data without the spaces and the comments
+	var prev byte
+	for i := 0; i < len(ret); i++ {
+		switch c := ret[i]; {
+		case c == '"':
+			comma, prev = -1, c
+			for i++; i < len(ret) && ret[i] != '"'; i++ {
+				if ret[i] == '\\' {
+					i++
+				}
+			}
+		case c == '/' && i+1 < len(ret) && ret[i+1] == '/':
+			for ; i < len(ret) && ret[i] != '\n'; i++ {
+				ret[i] = ' '
+			}
+		case c == '/' && i+1 < len(ret) && ret[i+1] == '*':
+			start := i
+			ret[i], ret[i+1] = ' ', ' '
+			for i += 2; i < len(ret) && !(ret[i] == '*' && i+1 < len(ret) && ret[i+1] == '/'); i++ {
+				if ret[i] != '\n' && ret[i] != '\r' {
+					ret[i] = ' '
+				}
+			}
+			if i < len(ret) {
+				ret[i], ret[i+1] = ' ', ' '
+				i++
+			} else {
+				// unterminated comment is the error of the decoding
+				ret[start], ret[start+1] = '/', '*'
+			}
+		case c == ',':
+			// the comma without the value before ('[,]', '[1,,]') is the error of the decoding
+			comma = -1
+			if prev != '[' && prev != '{' && prev != ',' && prev != ':' && prev != 0 {
+				comma = i
+			}
+			prev = c
+		case c == '}' || c == ']':
+			if comma >= 0 {
+				ret[comma] = ' '
+			}
+			comma, prev = -1, c
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		default:
+			comma, prev = -1, c
+		}
+	}
+	return ret
+}
+
+// decodeJSONC - decode JSON with comments and trailing commas (JSONC). Numbers as json.Number.
+// Other JSON5 syntax (unquoted keys, single quotes, hex numbers) is the error.
+// Data after the value is the error same as json.Unmarshal.
+// Error has the line and the column of the wrong data
+func decodeJSONC(data []byte, v any) error {
+	stripped := stripJSONC(data)
+	decoder := json.NewDecoder(bytes.NewReader(stripped))
+	// numbers as json.Number. Big integers without loss of precision
+	decoder.UseNumber()
+	err := decoder.Decode(v)
+	if err == nil {
+		return trailingData(data, stripped, decoder.InputOffset())
+	}
+	var offset int64 = -1
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	}
+	if offset <= 0 || offset > int64(len(data)) {
+		return err
+	}
+	// offset is after the wrong byte
+	line, column := position(data, offset-1)
+	return fmt.Errorf("line %d, column %d: %w", line, column, err)
+}
+
+// trailingData - error of the data after the decoded value
+func trailingData(data, stripped []byte, offset int64) error {
+	for i := offset; i < int64(len(stripped)); i++ {
+		switch stripped[i] {
+		case ' ', '\t', '\n', '\r':
+			continue
+		}
+		line, column := position(data, i)
+		return fmt.Errorf("line %d, column %d: invalid character %q after top-level value", line, column, data[i])
+	}
+	return nil
+}
+
+// position - line and column (from 1) of the offset
+func position(data []byte, offset int64) (line, column int) {
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = int(offset) - bytes.LastIndexByte(before, '\n')
+	return
+}
@@ -0,0 +1,130 @@
+package helpers
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSONC(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want map[string]any
+		// err - part of the error. Messages of encoding/json are not checked, only the position
+		err string
+	}{
+		{
+			name: "comments and trailing commas",
+			data: "{\n// line\n\"a\": 1, /* block */\n\"b\": [1, 2,],\n}",
+			want: map[string]any{"a": json.Number("1"), "b": []any{json.Number("1"), json.Number("2")}},
+		},
+		{
+			name: "comments inside strings",
+			data: `{"url": "http://host/*path*/", "c": "a // b"}`,
+			want: map[string]any{"url": "http://host/*path*/", "c": "a // b"},
+		},
+		{
+			name: "escaped quote before comment",
+			data: `{"q": "say \"hi\" //", "n": 1 // tail` + "\n}",
+			want: map[string]any{"q": `say "hi" //`, "n": json.Number("1")},
+		},
+		{
+			name: "comma inside string is not trailing",
+			data: `{"a": ",]"}`,
+			want: map[string]any{"a": ",]"},
+		},
+		{
+			name: "comment before trailing comma",
+			data: `{"a": [1 /* one */, // end` + "\n]}",
+			want: map[string]any{"a": []any{json.Number("1")}},
+		},
+		{
+			name: "comma without value in array",
+			data: `{"a": [,]}`,
+			err:  `line 1, column 8: `,
+		},
+		{
+			name: "comma without value in object",
+			data: `{,}`,
+			err:  `line 1, column 2: `,
+		},
+		{
+			name: "double comma",
+			data: `{"a": [1,,]}`,
+			err:  `line 1, column 10: `,
+		},
+		{
+			name: "comma without value of key",
+			data: `{"a":,}`,
+			err:  `line 1, column 6: `,
+		},
+		{
+			name: "trailing data",
+			data: "{\"a\": 1}\n  x",
+			err:  `line 2, column 3: invalid character 'x' after top-level value`,
+		},
+		{
+			name: "second value",
+			data: `{"a": 1} {"b": 2}`,
+			err:  `line 1, column 10: invalid character '{' after top-level value`,
+		},
+		{
+			name: "comment after value",
+			data: "{\"a\": 1} // end\n/* end */",
+			want: map[string]any{"a": json.Number("1")},
+		},
+		{
+			name: "missing comma",
+			data: "{\n\"a\": 1\n\"b\": 2}",
+			err:  "line 3, column 1: ",
+		},
+		{
+			name: "unterminated string",
+			data: `{"a": "b}`,
+			err:  "unexpected EOF",
+		},
+		{
+			name: "unterminated block comment",
+			data: `{"a": 1} /* end`,
+			err:  `line 1, column 10: invalid character '/' after top-level value`,
+		},
+		{
+			name: "unterminated block comment inside object",
+			data: "{\"a\": 1, /* b: 2}",
+			err:  `line 1, column 10: `,
+		},
+		{
+			name: "bad escape",
+			data: `{"a": "\q"}`,
+			err:  `line 1, column 9: `,
+		},
+		{
+			name: "unquoted key of JSON5",
+			data: `{a: 1}`,
+			err:  `line 1, column 2: `,
+		},
+		{
+			name: "single quotes of JSON5",
+			data: `{"a": 'b'}`,
+			err:  `line 1, column 7: `,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]any
+			err := decodeJSONC([]byte(tt.data), &got)
+			switch {
+			case tt.err != "":
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error %v, want %q", err, tt.err)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error %v", err)
+			case !reflect.DeepEqual(got, tt.want):
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Remote - request of the config document from the HTTP(S) endpoint
+type Remote struct {
+	URL     string
+	Timeout time.Duration
+	// Retries - count of the repeats after the network error or 5xx status
+	Retries int
+	// Token - bearer token. Empty - without authorization
+	Token string
+	// CacheFile - last successful response. Empty - without cache
+	CacheFile string
+	// Format - format of the document. Empty - by the Content-Type, the extension of the URL or the content
+	Format string
+	Client *http.Client
+}
+
+// metaSuffix - suffix of the file with the format and the ETag of the cached response
+const metaSuffix = ".meta"
+
+// retryDelay - delay before the next attempt, growing with every attempt
+const retryDelay = 100 * time.Millisecond
+
+/*
+FetchRemote return the settings of the config document of the endpoint and the format of it.
+The ETag of the cached response is sending as If-None-Match, 304 status returns the cached document.
+Only the document without the parse errors is cached.
+The cached document is returning if the endpoint is not available or the document is broken.
+The error is returning too, but with the settings
+*/
+func FetchRemote(r Remote) (map[string]any, string, error) {
+	cached, cachedFormat, etag := r.readCache()
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	var err error
+	for attempt := 0; attempt <= r.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * retryDelay)
+		}
+		var data []byte
+		var format, newEtag string
+		var retry bool
+		data, format, newEtag, retry, err = r.fetch(client, cached, etag)
+		if err == nil && data == nil {
+			// not modified
+			return decodeCache(cached, cachedFormat, nil)
+		}
+		if err == nil {
+			var settings map[string]any
+			if settings, err = DecodeSettings(data, format); err == nil {
+				r.writeCache(data, format, newEtag)
+				return settings, format, nil
+			}
+			err = fmt.Errorf("parse error: %w", err)
+		}
+		if !retry {
+			break
+		}
+	}
+	err = fmt.Errorf("remote config '%s': %w", r.URL, err)
+	if cached != nil {
+		return decodeCache(cached, cachedFormat, err)
+	}
+	return nil, "", err
+}
+
+// decodeCache - settings of the cached document. The error of the fetching is returning with the settings
+func decodeCache(cached []byte, format string, err error) (map[string]any, string, error) {
+	settings, errCache := DecodeSettings(cached, format)
+	if errCache != nil {
+		return nil, format, errors.Join(err, fmt.Errorf("remote config cache parse error: %w", errCache))
+	}
+	return settings, format, err
+}
+
+// fetch - one attempt. Data is nil for 304 status. Retry is true for the network error and 5xx status
+func (r Remote) fetch(client *http.Client, cached []byte, etag string) (data []byte, format, newEtag string, retry bool, err error) {
+	ctx := context.Background()
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.URL, nil)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	if cached != nil && etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if r.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", "", true, err
+	}
+	defer func() {
+		ToLog(resp.Body.Close(), "response body close error")
+	}()
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		return nil, "", etag, false, nil
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return nil, "", "", true, fmt.Errorf("status %s", resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return nil, "", "", false, fmt.Errorf("status %s", resp.Status)
+	}
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", "", true, err
+	}
+	format = r.Format
+	if format == "" {
+		format = remoteFormat(resp, data)
+	}
+	return data, format, resp.Header.Get("ETag"), false, nil
+}
+
+// remoteFormat - format by the Content-Type, the extension of the URL or the content
+func remoteFormat(resp *http.Response, data []byte) string {
+	typ, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case strings.Contains(typ, "yaml"):
+		return FormatYAML
+	case strings.Contains(typ, "toml"):
+		return FormatTOML
+	case strings.Contains(typ, "json"):
+		return FormatJSON
+	}
+	if ext := path.Ext(resp.Request.URL.Path); ext != "" {
+		return FileFormat(ext)
+	}
+	return ContentFormat(data)
+}
+
+// readCache - cached document, format and ETag
+func (r Remote) readCache() ([]byte, string, string) {
+	if r.CacheFile == "" {
+		return nil, "", ""
+	}
+	data, err := os.ReadFile(r.CacheFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			ToLog(err, fmt.Sprintf("remote config cache '%s' error", r.CacheFile))
+		}
+		return nil, "", ""
+	}
+	meta, _ := os.ReadFile(r.CacheFile + metaSuffix)
+	format, etag, _ := strings.Cut(string(meta), "\n")
+	if r.Format != "" {
+		format = r.Format
+	}
+	if format == "" {
+		format = ContentFormat(data)
+	}
+	return data, format, etag
+}
+
+// writeCache - save the document, format and ETag
+func (r Remote) writeCache(data []byte, format, etag string) {
+	if r.CacheFile == "" {
+		return
+	}
+	err := os.MkdirAll(filepath.Dir(r.CacheFile), 0o700)
+	if err == nil {
+		err = os.WriteFile(r.CacheFile, data, 0o600)
+	}
+	if err == nil {
+		err = os.WriteFile(r.CacheFile+metaSuffix, []byte(format+"\n"+etag), 0o600)
+	}
+	ToLog(err, fmt.Sprintf("remote config cache '%s' error", r.CacheFile))
+}
@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AppName - name of the executable without the extension
+func AppName() string {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = os.Args[0]
+	}
+	name := filepath.Base(exe)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+/*
+SearchPaths - default directories of the config file for the application:
+  - working directory
+  - $XDG_CONFIG_HOME/<app> (if the environment is set)
+  - ~/.config/<app>
+  - /etc/<app>
+  - directory of the executable
+*/
+func SearchPaths(app string) []string {
+	ret := []string{"."}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		ret = append(ret, filepath.Join(xdg, app))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		ret = append(ret, filepath.Join(home, ".config", app))
+	}
+	ret = append(ret, filepath.Join("/etc", app))
+	if exe, err := os.Executable(); err == nil {
+		ret = append(ret, filepath.Dir(exe))
+	}
+	return ret
+}
+
+// SearchConfigFiles - config files with the names inside the directories. Only the first directory with the files if not 'all'.
+// Names with the directory ("conf/app.json") are using as is
+func SearchConfigFiles(fsys fs.FS, names, dirs []string, all bool) []string {
+	var ret, search []string
+	for _, name := range names {
+		name = separatorCorrect(name)
+		if filepath.Base(name) != name {
+			ret = append(ret, ValidConfigFiles(fsys, []string{name})...)
+			continue
+		}
+		search = append(search, name)
+	}
+	for _, dir := range dirs {
+		var found []string
+		for _, name := range search {
+			found = append(found, ValidConfigFiles(fsys, []string{filepath.Join(expandHome(dir), name)})...)
+		}
+		ret = append(ret, found...)
+		if len(found) > 0 && !all {
+			break
+		}
+	}
+	return ret
+}
+
+// expandHome - "~/path" to the path inside the home directory
+func expandHome(dir string) string {
+	if dir != "~" && !strings.HasPrefix(dir, "~/") {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return dir
+	}
+	return filepath.Join(home, dir[1:])
+}
@@ -0,0 +1,28 @@
+package helpers
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Stdin - name of the config file for the standard input ("-config=-")
+const Stdin = "-"
+
+var (
+	stdinOnce sync.Once
+	stdinData []byte
+	stdinErr  error
+)
+
+// ReadStdin return the content of the standard input. Reading one time, the next calls return the same content
+func ReadStdin() ([]byte, error) {
+	stdinOnce.Do(func() {
+		stdinData, stdinErr = io.ReadAll(os.Stdin)
+		if stdinErr != nil {
+			stdinErr = fmt.Errorf("standard input is not access: %w", stdinErr)
+		}
+	})
+	return stdinData, stdinErr
+}
@@ -6,7 +6,7 @@ const (
 	// FLAG Get data from flags
 	FLAG Stages = iota + 1
 
-	// FILE Get data from json file
+	// FILE Get data from config file
 	FILE
 
 	// ENV Get data from environments
@@ -25,6 +25,27 @@ const (
 	// PreloadConfigEnv - Get filepath config from environments
 	PreloadConfigEnv
 
-	// NoPreloadConfig - Get filepath config file only from ordered stages
+	// NoPreloadConfig - Get filepath config file only from ordered stages. DIR, REMOTE and KV are not using for it
 	NoPreloadConfig
+
+	// DOTENV Get data from dotenv file
+	DOTENV
+
+	// EnvFromFile - Get value from the file of <ENV>_FILE environment (Docker/Kubernetes secrets)
+	EnvFromFile
+
+	// DIR Get data from directory with file per value (Kubernetes ConfigMap)
+	DIR
+
+	// RequireConfig - Config file is mandatory. Missing, unreadable or broken file is the error
+	RequireConfig
+
+	// INLINE Get data from config document inside environment
+	INLINE
+
+	// REMOTE Get data from config document of HTTP(S) endpoint
+	REMOTE
+
+	// KV Get data from key/value sources
+	KV
 )
@@ -0,0 +1,117 @@
+package tags
+
+import (
+	"errors"
+	"flag"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// terminator - end of the flags. Everything after is positional arguments
+const terminator = "--"
+
+// Argument - flag with value from the command line
+type Argument struct {
+	Name  string
+	Value string
+}
+
+/*
+ParseArgs - parse the command line arguments (without the program name) with the known flags.
+Supported:
+  - -name=value and --name=value
+  - -name value and --name value (not for bool flags)
+  - -name and --name for bool flags
+  - '--' - everything after is positional arguments
+
+Unknown flags are skipped. Their value is only taken from '-name=value',
+but flags of the 'other' flag sets (flags of the parent command) are skipped with the value.
+Return flags in the order of the command line and positional arguments
+*/
+func ParseArgs(args []string, t Tags, other ...*flag.FlagSet) (flags []Argument, positional []string) {
+	known := make(map[string]bool)
+	for _, v := range t {
+		for name := range v.Flags {
+			known[name] = v.store.Type.Type.Kind() == reflect.Bool
+		}
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == terminator {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			positional = append(positional, arg)
+			continue
+		}
+		name := strings.TrimPrefix(arg[1:], "-")
+		if name == "" || name[0] == '-' || name[0] == '=' {
+			// not a flag: '---name', '-=value'
+			positional = append(positional, arg)
+			continue
+		}
+		value, hasValue := "", false
+		if k := strings.Index(name, "="); k > 0 {
+			name, value, hasValue = name[:k], name[k+1:], true
+		}
+		isBool, ok := known[name]
+		switch {
+		case !ok:
+			// unknown flag. Skip the value of the other flag sets
+			if !hasValue && NeedsValue(name, nil, other...) && i+1 < len(args) {
+				i++
+			}
+			continue
+		case hasValue:
+		case isBool:
+			value = "true"
+		case i+1 < len(args):
+			i++
+			value = args[i]
+		default:
+			// flag without value at the end
+			continue
+		}
+		flags = append(flags, Argument{Name: name, Value: value})
+	}
+	return
+}
+
+// ErrMissingArgument - the positional argument by index without 'default' tag is not set
+var ErrMissingArgument = errors.New("positional argument is missing")
+
+// Positional - set the positional arguments to the fields with 'arg' tag.
+// 'arg:"rest"' gets all arguments after the last index
+func Positional(t Tags, positional []string) {
+	rest := 0
+	for _, v := range t {
+		if i, err := strconv.Atoi(v.arg); err == nil && i >= rest {
+			rest = i + 1
+		}
+	}
+	for _, v := range t {
+		if v.Arg != nil {
+			v.Arg(positional, rest)
+		}
+	}
+}
+
+// NeedsValue - flag of the tags or of the flag sets is not bool: '-name value' takes the next argument
+func NeedsValue(name string, t Tags, other ...*flag.FlagSet) bool {
+	for _, v := range t {
+		if _, ok := v.Flags[name]; ok {
+			return v.store.Type.Type.Kind() != reflect.Bool
+		}
+	}
+	for _, fs := range other {
+		if f := fs.Lookup(name); f != nil {
+			if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+				return false
+			}
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package tags
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+)
+
+type argsConfig struct {
+	Port    int    `flag:"port,p"`
+	Verbose bool   `flag:"v"`
+	Name    string `flag:"name"`
+}
+
+func TestParseArgs(t *testing.T) {
+	parent := flag.NewFlagSet("parent", flag.ContinueOnError)
+	parent.String("log", "", "")
+	parent.Bool("debug", false, "")
+	tests := []struct {
+		name       string
+		args       []string
+		other      []*flag.FlagSet
+		flags      []Argument
+		positional []string
+	}{
+		{
+			name:  "forms of the values",
+			args:  []string{"-port=1", "--p", "2", "-name", "a b", "--name=c=d", "-v"},
+			flags: []Argument{{"port", "1"}, {"p", "2"}, {"name", "a b"}, {"name", "c=d"}, {"v", "true"}},
+		},
+		{
+			name:  "bool with value",
+			args:  []string{"-v=false"},
+			flags: []Argument{{"v", "false"}},
+		},
+		{
+			name:       "bool does not take the next argument",
+			args:       []string{"-v", "file"},
+			flags:      []Argument{{"v", "true"}},
+			positional: []string{"file"},
+		},
+		{
+			name:       "value looks like a flag",
+			args:       []string{"-name", "-v", "in"},
+			flags:      []Argument{{"name", "-v"}},
+			positional: []string{"in"},
+		},
+		{
+			name:       "terminator",
+			args:       []string{"in", "-port=1", "--", "-v", "--"},
+			flags:      []Argument{{"port", "1"}},
+			positional: []string{"in", "-v", "--"},
+		},
+		{
+			name:  "value without flag at the end",
+			args:  []string{"-v", "-port"},
+			flags: []Argument{{"v", "true"}},
+		},
+		{
+			name:       "not flags",
+			args:       []string{"-", "---port=1", "-=1"},
+			positional: []string{"-", "---port=1", "-=1"},
+		},
+		{
+			name:       "unknown flags are skipped without value",
+			args:       []string{"-unknown", "in", "-other=1"},
+			positional: []string{"in"},
+		},
+		{
+			name:       "flags of the other flag sets are skipped with value",
+			args:       []string{"-log", "file.log", "-debug", "in", "-port", "1"},
+			other:      []*flag.FlagSet{parent},
+			flags:      []Argument{{"port", "1"}},
+			positional: []string{"in"},
+		},
+	}
+	tags := Struct[argsConfig]()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags, positional := ParseArgs(tt.args, tags, tt.other...)
+			if !reflect.DeepEqual(flags, tt.flags) {
+				t.Errorf("flags = %v, want %v", flags, tt.flags)
+			}
+			if !reflect.DeepEqual(positional, tt.positional) {
+				t.Errorf("positional = %q, want %q", positional, tt.positional)
+			}
+		})
+	}
+}
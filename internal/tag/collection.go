@@ -0,0 +1,126 @@
+package tags
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	sepTag   = "sep"
+	mergeTag = "merge"
+
+	// defaultSep - separator of the values of the slice and map
+	defaultSep = ","
+
+	// mergeAppend - values of the next source are appending to the values of the previous source
+	mergeAppend = "append"
+	// mergeReplace - values of the next source replace the values of the previous source. Default
+	mergeReplace = "replace"
+)
+
+// isCollection - slice or map. Types with UnmarshalText and []byte are single values
+func isCollection(typ reflect.Type) bool {
+	if isText(typ) {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Slice:
+		return typ.Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
+
+/*
+comparatorCollection - parse the text to the slice or map.
+  - slice: "a,b,c"
+  - map: "key1=value1,key2=value2"
+
+Every element is converting to the type of the element
+*/
+func comparatorCollection(typ reflect.Type, value, sep string) (any, error) {
+	if sep == "" {
+		sep = defaultSep
+	}
+	if value == "" {
+		return reflect.Zero(typ).Interface(), nil
+	}
+	items := strings.Split(value, sep)
+	switch typ.Kind() {
+	case reflect.Slice:
+		ret := reflect.MakeSlice(typ, 0, len(items))
+		for _, v := range items {
+			elem, err := comparatorStringType(nil, reflect.StructField{Type: typ.Elem()}, strings.TrimSpace(v), false)
+			if err != nil {
+				return reflect.Zero(typ).Interface(), fmt.Errorf("element '%s': %w", v, err)
+			}
+			ret = reflect.Append(ret, reflect.ValueOf(elem))
+		}
+		return ret.Interface(), nil
+	case reflect.Map:
+		ret := reflect.MakeMapWithSize(typ, len(items))
+		for _, v := range items {
+			k, e, ok := strings.Cut(v, "=")
+			if !ok {
+				return reflect.Zero(typ).Interface(), fmt.Errorf("element '%s': want key=value", v)
+			}
+			key, err := comparatorStringType(nil, reflect.StructField{Type: typ.Key()}, strings.TrimSpace(k), false)
+			if err != nil {
+				return reflect.Zero(typ).Interface(), fmt.Errorf("key '%s': %w", k, err)
+			}
+			elem, err := comparatorStringType(nil, reflect.StructField{Type: typ.Elem()}, strings.TrimSpace(e), false)
+			if err != nil {
+				return reflect.Zero(typ).Interface(), fmt.Errorf("value of the key '%s': %w", k, err)
+			}
+			ret.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(elem))
+		}
+		return ret.Interface(), nil
+	}
+	return reflect.Zero(typ).Interface(), fmt.Errorf("type %s is not slice or map", typ)
+}
+
+// combine - result of the new value of the slice or map with the current value.
+// Appending inside one source (repeated flags) and between sources with 'merge:"append"'
+func (s *storage) combine(value any, text string) (any, string) {
+	if !isCollection(s.Type.Type) || (s.fresh && s.Merge != mergeAppend) {
+		return value, text
+	}
+	if s.StoreString != "" && text != "" {
+		sep := s.Sep
+		if sep == "" {
+			sep = defaultSep
+		}
+		text = s.StoreString + sep + text
+	} else if text == "" {
+		text = s.StoreString
+	}
+	return appendCollection(s.Store, value), text
+}
+
+// appendCollection - append slice 'b' to slice 'a' or copy map 'a' and set keys of map 'b'
+func appendCollection(a, b any) any {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || va.IsZero() {
+		return b
+	}
+	if !vb.IsValid() || vb.IsZero() {
+		return a
+	}
+	switch va.Kind() {
+	case reflect.Slice:
+		ret := reflect.MakeSlice(va.Type(), 0, va.Len()+vb.Len())
+		return reflect.AppendSlice(reflect.AppendSlice(ret, va), vb).Interface()
+	case reflect.Map:
+		ret := reflect.MakeMapWithSize(va.Type(), va.Len()+vb.Len())
+		for _, m := range []reflect.Value{va, vb} {
+			iter := m.MapRange()
+			for iter.Next() {
+				ret.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+		return ret.Interface()
+	}
+	return b
+}
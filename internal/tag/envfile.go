@@ -0,0 +1,67 @@
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/KusoKaihatsuSha/startup/internal/helpers"
+	"github.com/KusoKaihatsuSha/startup/internal/order"
+)
+
+// envFileTag - 'true' or 'false'. Reading the value from the file of <ENV>_FILE environment for the field
+const envFileTag = "envfile"
+
+// envFileSuffix - suffix of the environment with the filepath of the value (DB_PASSWORD_FILE=/run/secrets/db)
+const envFileSuffix = "_FILE"
+
+// ErrEnvConflict - both the environment and the environment with the filepath of the value are set
+var ErrEnvConflict = errors.New("both environment and its _FILE are set")
+
+// envFileEnabled - tag 'envfile' of the field or the stage EnvFromFile
+func envFileEnabled(field reflect.StructField, stages []order.Stages) bool {
+	if v, ok := field.Tag.Lookup(envFileTag); ok {
+		enabled, err := strconv.ParseBool(v)
+		return err == nil && enabled
+	}
+	return slices.Contains(stages, order.EnvFromFile)
+}
+
+// envValue - value of the environment 'name' or the trimmed content of the file from <name>_FILE.
+// Return the filepath as the value with the error of the reading
+func envValue(name string, envFile bool, lookup func(string) (string, bool), fsys fs.FS) (string, bool, error) {
+	value, ok := lookup(name)
+	if !envFile {
+		return value, ok, nil
+	}
+	path, okFile := lookup(name + envFileSuffix)
+	switch {
+	case !okFile:
+		return value, ok, nil
+	case ok:
+		return "", false, fmt.Errorf("%w: '%s' and '%s'", ErrEnvConflict, name, name+envFileSuffix)
+	}
+	data, err := helpers.ReadFile(fsys, path)
+	if err != nil {
+		return path, false, fmt.Errorf("file of '%s': %w", name+envFileSuffix, err)
+	}
+	return strings.TrimSpace(string(data)), true, nil
+}
+
+// setEnv - set the value of the environment (process or dotenv file) to the storage.
+// Files of <ENV>_FILE are reading from the file system
+func (s *storage) setEnv(source string, envFile bool, lookup func(string) (string, bool), fsys fs.FS) {
+	value, ok, err := envValue(s.Env, envFile, lookup, fsys)
+	if err != nil {
+		s.begin(source)
+		s.fail(source, err, value)
+		return
+	}
+	if ok {
+		_ = s.setFrom(source, value)
+	}
+}
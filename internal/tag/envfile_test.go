@@ -0,0 +1,81 @@
+package tags
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestEnvValue(t *testing.T) {
+	fsys := fstest.MapFS{
+		"secrets/db":    {Data: []byte("  secret\n")},
+		"secrets/empty": {Data: []byte("\n")},
+	}
+	tests := []struct {
+		name    string
+		env     map[string]string
+		envFile bool
+		value   string
+		ok      bool
+		err     error
+	}{
+		{
+			name:  "environment",
+			env:   map[string]string{"DB": "plain", "DB_FILE": "secrets/db"},
+			value: "plain",
+			ok:    true,
+		},
+		{
+			name: "not set",
+		},
+		{
+			name:    "file of the value",
+			env:     map[string]string{"DB_FILE": "secrets/db"},
+			envFile: true,
+			value:   "secret",
+			ok:      true,
+		},
+		{
+			name:    "empty file",
+			env:     map[string]string{"DB_FILE": "secrets/empty"},
+			envFile: true,
+			ok:      true,
+		},
+		{
+			name:    "environment without file",
+			env:     map[string]string{"DB": "plain"},
+			envFile: true,
+			value:   "plain",
+			ok:      true,
+		},
+		{
+			name:    "both are set",
+			env:     map[string]string{"DB": "plain", "DB_FILE": "secrets/db"},
+			envFile: true,
+			err:     ErrEnvConflict,
+		},
+		{
+			name:    "missing file",
+			env:     map[string]string{"DB_FILE": "secrets/missing"},
+			envFile: true,
+			value:   "secrets/missing",
+			err:     fs.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := func(name string) (string, bool) {
+				v, ok := tt.env[name]
+				return v, ok
+			}
+			value, ok, err := envValue("DB", tt.envFile, lookup, fsys)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+			if value != tt.value || ok != tt.ok {
+				t.Errorf("got (%q, %v), want (%q, %v)", value, ok, tt.value, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package tags
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sources of the values. Using in FieldError
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceInline  = "inline"
+	SourceRemote  = "remote"
+	SourceDotEnv  = "dotenv"
+	SourceDir     = "dir"
+	SourceKV      = "kv"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+	SourceArg     = "arg"
+	SourceValid   = "valid"
+)
+
+// sourceOrder - order of the sources in the error list
+var sourceOrder = []string{
+	SourceDefault,
+	SourceFile,
+	SourceInline,
+	SourceRemote,
+	SourceDir,
+	SourceKV,
+	SourceDotEnv,
+	SourceEnv,
+	SourceFlag,
+	SourceArg,
+	SourceValid,
+}
+
+// FieldError - information about the value which was not set to the field
+type FieldError struct {
+	// Field - name of the field in the struct
+	Field string
+	// Source - where the value came from (default, file, inline, remote, dir, kv, dotenv, env, flag, arg, valid)
+	Source string
+	// Name - flag, environment or JSON key name of the value
+	Name string
+	// Value - raw value
+	Value string
+	// Err - reason
+	Err error
+}
+
+// Error - error interface implementation
+func (e *FieldError) Error() string {
+	var b strings.Builder
+	if e.Field != "" {
+		b.WriteString(e.Field)
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Source)
+	if e.Name != "" {
+		b.WriteString(fmt.Sprintf(" '%s'", e.Name))
+	}
+	if e.Value != "" {
+		b.WriteString(fmt.Sprintf(" value '%s'", e.Value))
+	}
+	b.WriteString(": ")
+	b.WriteString(fmt.Sprint(e.Err))
+	return b.String()
+}
+
+// Unwrap - return reason
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Errors - all errors of the one loading
+type Errors []*FieldError
+
+// Error - error interface implementation
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return "startup: " + e[0].Error()
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("startup: %d configuration errors:", len(e)))
+	for _, v := range e {
+		b.WriteString("\n\t")
+		b.WriteString(v.Error())
+	}
+	return b.String()
+}
+
+// Unwrap - return all errors for errors.Is and errors.As
+func (e Errors) Unwrap() []error {
+	ret := make([]error, 0, len(e))
+	for _, v := range e {
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+// Sort - sorting by field and then by source
+func (e Errors) Sort() {
+	sort.SliceStable(e, func(i, j int) bool {
+		if e[i].Field != e[j].Field {
+			return e[i].Field < e[j].Field
+		}
+		return sourceIndex(e[i].Source) < sourceIndex(e[j].Source)
+	})
+}
+
+func sourceIndex(source string) int {
+	for k, v := range sourceOrder {
+		if v == source {
+			return k
+		}
+	}
+	return len(sourceOrder)
+}
@@ -0,0 +1,98 @@
+package tags
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFieldError(t *testing.T) {
+	reason := errors.New("reason")
+	tests := []struct {
+		name string
+		err  *FieldError
+		want string
+	}{
+		{
+			name: "all parts",
+			err:  &FieldError{Field: "Port", Source: SourceFlag, Name: "-port", Value: "x", Err: reason},
+			want: "Port: flag '-port' value 'x': reason",
+		},
+		{
+			name: "without field, name and value",
+			err:  &FieldError{Source: SourceFile, Err: reason},
+			want: "file: reason",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !errors.Is(tt.err, reason) {
+				t.Error("reason is not unwrapped")
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	reason := errors.New("reason")
+	valid := &FieldError{Field: "A", Source: SourceValid, Err: reason}
+	flagErr := &FieldError{Field: "A", Source: SourceFlag, Name: "-a", Err: ErrEnvConflict}
+	file := &FieldError{Field: "A", Source: SourceFile, Err: reason}
+	other := &FieldError{Field: "B", Source: "unknown", Err: reason}
+	first := &FieldError{Source: SourceFile, Err: reason}
+
+	errs := Errors{other, valid, flagErr, file, first}
+	errs.Sort()
+	want := Errors{first, file, flagErr, valid, other}
+	for k := range want {
+		if errs[k] != want[k] {
+			t.Fatalf("sorted %d = %v, want %v", k, errs[k], want[k])
+		}
+	}
+
+	if got := (Errors{valid}).Error(); got != "startup: A: valid: reason" {
+		t.Errorf("single error = %q", got)
+	}
+	wantText := "startup: 2 configuration errors:\n\tA: file: reason\n\tA: flag '-a': both environment and its _FILE are set"
+	if got := (Errors{file, flagErr}).Error(); got != wantText {
+		t.Errorf("several errors = %q, want %q", got, wantText)
+	}
+
+	var err error = errs
+	if !errors.Is(err, ErrEnvConflict) {
+		t.Error("errors.Is does not find the reason")
+	}
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr != first {
+		t.Errorf("errors.As = %v, want %v", fieldErr, first)
+	}
+}
+
+type errorsConfig struct {
+	Port int `flag:"errors-port" env:"ERRORS_PORT" default:"1"`
+}
+
+func TestTagErrors(t *testing.T) {
+	t.Setenv("ERRORS_PORT", "env")
+	tag := Struct[errorsConfig]()["Port"]
+	tag.Env(nil)
+	tag.Flag([]Argument{{"errors-port", "flag"}})
+	errs := tag.Errors()
+	if len(errs) != 2 || errs[0].Source != SourceEnv || errs[1].Source != SourceFlag {
+		t.Fatalf("errors = %v", errs)
+	}
+	if errs[0].Name != "ERRORS_PORT" || errs[0].Value != "env" || errs[1].Name != "-errors-port" || errs[1].Value != "flag" {
+		t.Errorf("errors = %v", errs)
+	}
+
+	// the correct value of the source clears the error
+	tag.Flag([]Argument{{"errors-port", "2"}})
+	if errs = tag.Errors(); len(errs) != 1 || errs[0].Source != SourceEnv {
+		t.Errorf("errors = %v", errs)
+	}
+	if tag.Value() != 2 {
+		t.Errorf("value = %v", tag.Value())
+	}
+}
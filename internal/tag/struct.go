@@ -0,0 +1,277 @@
+package tags
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/KusoKaihatsuSha/startup/internal/order"
+)
+
+// prefixTag - prefix of the names inside the nested struct
+const prefixTag = "prefix"
+
+// Prefix - names of the parent structs
+type Prefix struct {
+	// Flag - "db."
+	Flag string
+	// Env - "DB_"
+	Env string
+	// JSON - ["db"]
+	JSON []string
+	// File - {"yaml": ["database"]}. Names of the config file formats with the own tags
+	File map[string][]string
+	// Skip - the parent struct has 'json:"-"'. Fields are not read from the config documents
+	Skip bool
+}
+
+// file - names of the parents for the config file format. Same as JSON if the format has not own names
+func (p Prefix) file(format string) []string {
+	if v, ok := p.File[format]; ok {
+		return v
+	}
+	return p.JSON
+}
+
+/*
+Struct - filling the 'tags' of all fields of the struct T.
+Nested and embedded structs are filling recursively, the names are getting the prefix:
+
+	type Config struct {
+		Database DBConfig `json:"db"`     // -db.host, DB_HOST, {"db":{"host":""}}
+		Cache    DBConfig `prefix:"redis"` // -redis.host, REDIS_HOST, {"redis":{"host":""}}
+		DBConfig                           // -host, HOST, {"host":""}
+	}
+
+The key of the map is the path of the field ("Database.Host").
+The field with the struct of the parents (type Node struct{ Next *Node }) is skipped
+*/
+func Struct[T any](order ...order.Stages) Tags {
+	ret := make(Tags)
+	fill(ret, reflect.TypeOf((*T)(nil)).Elem(), "", Prefix{}, make(map[reflect.Type]bool), order...)
+	return ret
+}
+
+// fill - tags of the fields of the struct. Parents - types of the structs on the path to the field
+func fill(t Tags, typ reflect.Type, path string, prefix Prefix, parents map[reflect.Type]bool, order ...order.Stages) {
+	if typ.Kind() != reflect.Struct || parents[typ] {
+		return
+	}
+	parents[typ] = true
+	defer delete(parents, typ)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := path + field.Name
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		if nested, ok := nestedPrefix(field, prefix); ok {
+			fill(t, indirect(field.Type), name+".", nested, parents, order...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		t[name] = Fill(name, field, prefix, order...)
+	}
+}
+
+// nestedPrefix - prefix of the nested struct. False if the field is not nested struct
+func nestedPrefix(field reflect.StructField, prefix Prefix) (Prefix, bool) {
+	typ := indirect(field.Type)
+	if typ.Kind() != reflect.Struct || isText(typ) || isJSONUnmarshaler(typ) {
+		return prefix, false
+	}
+	p, hasPrefix := field.Tag.Lookup(prefixTag)
+	if !hasPrefix {
+		// the field with own settings is not nested
+		for _, v := range []string{flagTag, EnvironmentTag, defaultTag, argTag, validationTag} {
+			if _, ok := field.Tag.Lookup(v); ok {
+				return prefix, false
+			}
+		}
+	}
+	jsonKey := jsonName(field)
+	skip := prefix.Skip || field.Tag.Get(jsonTag) == "-"
+	switch {
+	case hasPrefix:
+	case field.Anonymous && skip:
+		// embedded struct without the config documents
+		p = ""
+	case field.Anonymous:
+		// embedded struct without prefix
+		if jsonKey == "" {
+			return prefix, true
+		}
+		p = jsonKey
+	case jsonKey != "":
+		p = jsonKey
+	default:
+		p = strings.ToLower(field.Name)
+	}
+	if jsonKey == "" && !skip {
+		jsonKey = p
+	}
+	ret := Prefix{
+		Flag: prefix.Flag,
+		Env:  prefix.Env,
+		JSON: append([]string(nil), prefix.JSON...),
+		File: make(map[string][]string),
+		Skip: skip,
+	}
+	if p != "" {
+		ret.Flag += p + "."
+		ret.Env += strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(p)) + "_"
+	}
+	if jsonKey != "" {
+		ret.JSON = append(ret.JSON, jsonKey)
+	}
+	for _, format := range fileTags {
+		ret.File[format] = append([]string(nil), prefix.file(format)...)
+		key := keyName(field, format)
+		if key == "" {
+			key = jsonKey
+		}
+		if key != "" {
+			ret.File[format] = append(ret.File[format], key)
+		}
+	}
+	return ret, true
+}
+
+// jsonName - name from the 'json' tag without options
+func jsonName(field reflect.StructField) string {
+	return keyName(field, jsonTag)
+}
+
+// keyName - name from the tag ('json', 'yaml') without options
+func keyName(field reflect.StructField, tag string) string {
+	v, ok := field.Tag.Lookup(tag)
+	if !ok {
+		return ""
+	}
+	v, _, _ = strings.Cut(v, ",")
+	if v == "-" {
+		return ""
+	}
+	return v
+}
+
+/*
+formatKeys - the keys of the decoded document in the names of the format tags ('yaml', 'toml') are renaming
+to the JSON names of the fields. The structs inside the slices and the maps are decoding through JSON:
+
+	type Server struct {
+		HostName string `toml:"host_name"`
+	}
+
+	[[servers]] host_name = "a" => [{"HostName": "a"}]
+
+The value is not changed for the other formats
+*/
+func formatKeys(value any, typ reflect.Type, format string) any {
+	if !slices.Contains(fileTags, format) {
+		return value
+	}
+	typ = indirect(typ)
+	if isText(typ) || isJSONUnmarshaler(typ) {
+		return value
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		ret := maps.Clone(m)
+		for _, field := range reflect.VisibleFields(typ) {
+			if !field.IsExported() || (field.Anonymous && jsonName(field) == "") {
+				// fields of the embedded struct are promoted
+				continue
+			}
+			key := jsonName(field)
+			if key == "" {
+				key = field.Name
+			}
+			name := keyName(field, format)
+			if v, ok := m[name]; ok && name != "" {
+				delete(ret, name)
+				ret[key] = formatKeys(v, field.Type, format)
+			} else if v, ok := m[key]; ok {
+				ret[key] = formatKeys(v, field.Type, format)
+			}
+		}
+		return ret
+	case reflect.Slice, reflect.Array:
+		// arrays of the tables of TOML are []map[string]any
+		list := reflect.ValueOf(value)
+		if list.Kind() != reflect.Slice {
+			return value
+		}
+		ret := make([]any, list.Len())
+		for k := range ret {
+			ret[k] = formatKeys(list.Index(k).Interface(), typ.Elem(), format)
+		}
+		return ret
+	case reflect.Map:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		ret := make(map[string]any, len(m))
+		for k, v := range m {
+			ret[k] = formatKeys(v, typ.Elem(), format)
+		}
+		return ret
+	}
+	return value
+}
+
+func indirect(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Pointer {
+		return typ.Elem()
+	}
+	return typ
+}
+
+// lookupPath - value of the nested maps by path
+func lookupPath(m map[string]any, path []string) (any, bool) {
+	if len(path) == 0 {
+		return nil, false
+	}
+	var cur any = m
+	for _, key := range path {
+		next, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = next[key]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// FieldByPath - field of the struct by path ("Database.Host"). Nil pointers of the nested structs are allocating
+func FieldByPath(v reflect.Value, path string) reflect.Value {
+	for _, name := range strings.Split(path, ".") {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+		v = v.FieldByName(name)
+		if !v.IsValid() {
+			return v
+		}
+	}
+	return v
+}
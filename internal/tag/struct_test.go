@@ -0,0 +1,108 @@
+package tags
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type node struct {
+	Name string `json:"name"`
+	Next *node  `json:"next"`
+	Tree struct {
+		Left  *node `json:"left"`
+		Value int   `json:"value"`
+	} `json:"tree"`
+}
+
+type pair struct {
+	First  leaf `json:"first"`
+	Second leaf `json:"second"`
+}
+
+type leaf struct {
+	Value int `json:"value"`
+}
+
+func TestStructNames(t *testing.T) {
+	tests := []struct {
+		name string
+		tags Tags
+		want []string
+	}{
+		{
+			name: "self-referential struct is skipped",
+			tags: Struct[node](),
+			want: []string{"Name", "Tree.Value"},
+		},
+		{
+			name: "same struct in sibling fields",
+			tags: Struct[pair](),
+			want: []string{"First.Value", "Second.Value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for k := range tt.tags {
+				got = append(got, k)
+			}
+			slices.Sort(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type server struct {
+	HostName string `yaml:"host_name" toml:"host"`
+	Port     int    `json:"port" yaml:"server_port"`
+	Inner    leaf   `yaml:"inner_leaf"`
+}
+
+func TestFormatKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		typ    reflect.Type
+		format string
+		want   any
+	}{
+		{
+			name:   "yaml list of structs",
+			value:  []any{map[string]any{"host_name": "a", "server_port": 1, "inner_leaf": map[string]any{"value": 2}}},
+			typ:    reflect.TypeOf([]server{}),
+			format: yamlTag,
+			want:   []any{map[string]any{"HostName": "a", "port": 1, "Inner": map[string]any{"value": 2}}},
+		},
+		{
+			name:   "toml array of tables",
+			value:  []map[string]any{{"host": "a", "port": 1}},
+			typ:    reflect.TypeOf([]*server{}),
+			format: tomlTag,
+			want:   []any{map[string]any{"HostName": "a", "port": 1}},
+		},
+		{
+			name:   "map of structs",
+			value:  map[string]any{"main": map[string]any{"host_name": "a"}},
+			typ:    reflect.TypeOf(map[string]server{}),
+			format: yamlTag,
+			want:   map[string]any{"main": map[string]any{"HostName": "a"}},
+		},
+		{
+			name:   "json is not changed",
+			value:  []any{map[string]any{"host_name": "a"}},
+			typ:    reflect.TypeOf([]server{}),
+			format: jsonTag,
+			want:   []any{map[string]any{"host_name": "a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatKeys(tt.value, tt.typ, tt.format); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
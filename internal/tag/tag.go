@@ -1,16 +1,21 @@
 package tags
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
-	"io"
+	"io/fs"
+	"math"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/fatih/color"
 
@@ -26,10 +31,20 @@ const (
 	helpTextTag    = "help"
 	validationTag  = "valid"
 	jsonTag        = "json"
+	yamlTag        = "yaml"
+	tomlTag        = "toml"
+	argTag         = "arg"
+
+	// argRest - value of the 'arg' tag for all remaining positional arguments
+	argRest = "rest"
 
 	testTrigger = "-test."
 )
 
+// fileTags - tags with the own key names for the config file format. Name of the tag is the format.
+// Key of the 'json' tag is using if the tag is not set
+var fileTags = []string{yamlTag, tomlTag}
+
 var durationTypesMap = map[string]string{
 	"ns": "Nanosecond",
 	"us": "Microsecond",
@@ -44,15 +59,23 @@ type Tags map[string]Tag
 
 // Tag of TagInfo store tags Config struct
 type Tag struct {
-	ConfigFile func(map[string]any) Tag
-	Valid      func() any
-	DummyFlags func() Tag
-	Env        func() Tag
-	Flag       func() Tag
+	ConfigFile func(map[string]any, string) Tag
+	Inline     func(map[string]any, string) Tag
+	Remote     func(map[string]any, string) Tag
+	DotEnv     func(map[string]string, fs.FS) Tag
+	Dir        func(map[string]string) Tag
+	KV         func(map[string]string) Tag
+	Valid      func(fs.FS) any
+	Errors     func() Errors
+	DummyFlags func(*flag.FlagSet) Tag
+	Env        func(fs.FS) Tag
+	Flag       func([]Argument) Tag
+	Arg        func([]string, int) Tag
 	FlagSet    *flag.FlagSet
 	Flags      map[string]*flag.Flag
 	Name       string
 	Annotation
+	store *storage
 }
 
 // Annotation - store general values
@@ -61,7 +84,15 @@ type Annotation struct {
 	json  string
 	desc  string
 	env   string
-	def   string
+	// envFile - reading <env>_FILE environment
+	envFile bool
+	def     string
+	arg     string
+	// required - positional argument by index without 'default' tag
+	required bool
+
+	jsonPath []string
+	filePath map[string][]string
 }
 
 type storage struct {
@@ -74,71 +105,234 @@ type storage struct {
 	Env         string
 	JSON        string
 	Name        string
+	Arg         string
+	Field       string
+	JSONPath    []string
+	Sep         string
+	Merge       string
+	// Valid - name of the custom validation. Text of the slice or the map with the custom validation is not parsed:
+	// the validation gets the text and returns the value
+	Valid  string
+	source string
+	fresh  bool
+	errs   map[string]*FieldError
 }
 
 // Set 'flag' interface implementation
 func (s *storage) Set(value string) error {
 	var err error
-	s.Store, err = comparatorStringType(nil, s.Type, value, s.Flag)
-	s.StoreString = value
+	switch {
+	case isCollection(s.Type.Type) && s.Valid != "":
+		s.Store, s.StoreString = reflect.Zero(s.Type.Type).Interface(), value
+	case isCollection(s.Type.Type):
+		var parsed any
+		parsed, err = comparatorCollection(s.Type.Type, value, s.Sep)
+		if err != nil {
+			s.Store, s.StoreString = parsed, value
+		} else {
+			s.Store, s.StoreString = s.combine(parsed, value)
+		}
+	default:
+		s.Store, err = comparatorStringType(nil, s.Type, value, s.Flag)
+		s.StoreString = value
+	}
+	s.fresh = false
+	s.fail(s.source, err, value)
 	helpers.ToLog("", fmt.Sprintf("set flag data '%s' %v", value, err)) // skip info and error parse
-	// skip error for custom types
+	// errors are collected inside the storage. See Tag.Errors
 	return nil
 }
 
+// setFrom - set value with the source of the value
+func (s *storage) setFrom(source, value string) error {
+	s.begin(source)
+	return s.Set(value)
+}
+
+// begin - start of the new source. Slices and maps will be replaced or appended (tag 'merge')
+func (s *storage) begin(source string) {
+	s.source = source
+	s.fresh = true
+}
+
+// setNative - set the decoded value of the config file (string, json.Number, bool, []any, map[string]any).
+// Objects, arrays and numbers are decoding straight to the type of the field.
+// Strings, numbers of the durations and the text types (30 is 30s) and values which can not be decoded
+// are going through Set as text.
+// Return the text of the value
+func (s *storage) setNative(source string, value any) string {
+	s.begin(source)
+	prev, prevText := s.Store, s.StoreString
+	text, isString := value.(string)
+	raw, err := json.Marshal(value)
+	if err != nil || (!isString && isScalar(value) && (isDuration(s.Type.Type) || isText(s.Type.Type))) {
+		text = fmt.Sprint(value)
+		helpers.ToLog(s.Set(text))
+		return text
+	}
+	if isString {
+		helpers.ToLog(s.Set(text))
+		if s.errs[source] == nil || !isJSONUnmarshaler(s.Type.Type) {
+			return text
+		}
+		// try json.Unmarshaler with the previous value
+		s.Store, s.StoreString, s.fresh = prev, prevText, true
+	} else {
+		text = string(raw)
+	}
+	ret := reflect.New(s.Type.Type)
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err = decoder.Decode(ret.Interface()); err != nil {
+		if !isString {
+			helpers.ToLog(s.Set(text))
+		}
+		return text
+	}
+	s.Store, s.StoreString = s.combine(ret.Elem().Interface(), text)
+	s.fresh = false
+	s.fail(source, nil, text)
+	return text
+}
+
+// setSlice - set the list of the values. Every value is converting to the type of the element of the slice.
+// Values are joining with the space for the other types
+func (s *storage) setSlice(values []string) {
+	typ := s.Type.Type
+	if typ.Kind() != reflect.Slice || isText(typ) {
+		helpers.ToLog(s.Set(strings.Join(values, " ")))
+		return
+	}
+	ret := reflect.MakeSlice(typ, 0, len(values))
+	for _, v := range values {
+		elem, err := comparatorStringType(nil, reflect.StructField{Type: typ.Elem()}, v, false)
+		if err != nil {
+			s.fail(s.source, err, v)
+			return
+		}
+		ret = reflect.Append(ret, reflect.ValueOf(elem))
+	}
+	s.Store, s.StoreString = s.combine(ret.Interface(), strings.Join(values, " "))
+	s.fresh = false
+	s.fail(s.source, nil, s.StoreString)
+}
+
+// fail - store or clear error of the source
+func (s *storage) fail(source string, err error, value string) {
+	if s.errs == nil {
+		s.errs = make(map[string]*FieldError)
+	}
+	if err == nil {
+		delete(s.errs, source)
+		return
+	}
+	name := ""
+	switch source {
+	case SourceFlag:
+		name = "-" + s.Name
+	case SourceEnv, SourceDotEnv:
+		name = s.Env
+	case SourceFile, SourceInline, SourceRemote:
+		name = s.JSON
+	case SourceDir, SourceKV:
+		name = s.Env
+		if name == "" {
+			name = s.JSON
+		}
+	case SourceArg:
+		name = s.Arg
+	}
+	s.errs[source] = &FieldError{
+		Field:  s.Field,
+		Source: source,
+		Name:   name,
+		Value:  value,
+		Err:    err,
+	}
+}
+
 // String - Stringer interface implementation
 func (s *storage) String() string {
 	return fmt.Sprint(s.Store)
 }
 
-// Fill - filling the 'tag'
-func Fill[T any](field string, order ...order.Stages) Tag {
-	var t T
+// IsBoolFlag - bool flag without argument
+func (s *storage) IsBoolFlag() bool {
+	return s.Type.Type.Kind() == reflect.Bool
+}
+
+// dummy - storage inside flag.CommandLine or the flag set of the subcommand. Values are already set by ParseArgs.
+// Using for the help and the check of the unknown flags
+type dummy struct {
+	*storage
+}
+
+// Set 'flag' interface implementation. Do nothing
+func (d dummy) Set(string) error {
+	return nil
+}
+
+// Fill - filling the 'tag' of the field.
+// 'field' is the path of the field ("Database.Host"), names of the flag, environment and JSON are getting the prefix
+func Fill(field string, fieldByName reflect.StructField, prefix Prefix, order ...order.Stages) Tag {
 	tagData := Tag{}
 
 	tagData.Flags = make(map[string]*flag.Flag)
 
 	tagData.Name = field
 	tagData.FlagSet = flag.NewFlagSet("", flag.ContinueOnError)
-	fieldByName, exist := reflect.TypeOf(t).FieldByName(tagData.Name)
-	if !exist {
-		return Tag{}
-	}
 	if v, ok := fieldByName.Tag.Lookup(helpTextTag); ok {
 		tagData.desc = v
 	}
 	if v, ok := fieldByName.Tag.Lookup(defaultTag); ok {
 		tagData.def = v
 	}
-	if v, ok := fieldByName.Tag.Lookup(EnvironmentTag); ok {
-		tagData.env = strings.ToUpper(v)
+	if v, ok := fieldByName.Tag.Lookup(EnvironmentTag); ok && v != "" {
+		tagData.env = strings.ToUpper(prefix.Env + v)
+		tagData.envFile = envFileEnabled(fieldByName, order)
 	}
-	if v, ok := fieldByName.Tag.Lookup(jsonTag); ok {
-		tagData.json = v
+	if v := jsonName(fieldByName); v != "" && !prefix.Skip {
+		tagData.jsonPath = append(append([]string(nil), prefix.JSON...), v)
+		tagData.json = strings.Join(tagData.jsonPath, ".")
 	}
-	if v, ok := fieldByName.Tag.Lookup(flagTag); ok {
-		fv := new(storage)
-		fv.Type = fieldByName
-		fv.Flag = true
-		fv.Default = tagData.def
-		fv.Env = tagData.env
-		fv.JSON = tagData.json
-		fv.Desc = tagData.desc
-		fv.Name = v
-		err := fv.Set(tagData.def)
-		helpers.ToLog("", fmt.Sprintf("set flag data '%s' %v", tagData.def, err)) // skip info and error parse
-
-		// handy bool flag without argument
-		if fv.Type.Type.Name() == "bool" {
-			for i, arg := range os.Args {
-				// only flag
-				if arg == "-"+fv.Name {
-					os.Args[i] = arg + "=true"
-				}
-			}
+	tagData.filePath = make(map[string][]string)
+	for _, format := range fileTags {
+		v := keyName(fieldByName, format)
+		if v == "" {
+			v = jsonName(fieldByName)
 		}
+		if v != "" && !prefix.Skip {
+			tagData.filePath[format] = append(append([]string(nil), prefix.file(format)...), v)
+		}
+	}
+
+	// storage of the value. Flags, environment and config file are using the same storage
+	fv := new(storage)
+	fv.Type = fieldByName
+	fv.Field = field
+	fv.Default = tagData.def
+	fv.Env = tagData.env
+	fv.JSON = tagData.json
+	fv.JSONPath = tagData.jsonPath
+	fv.Desc = tagData.desc
+	fv.Sep = fieldByName.Tag.Get(sepTag)
+	fv.Merge = fieldByName.Tag.Get(mergeTag)
+	if v := fieldByName.Tag.Get(validationTag); !validation.IsDefault(v) {
+		fv.Valid = v
+	}
+	err := fv.setFrom(SourceDefault, tagData.def)
+	helpers.ToLog("", fmt.Sprintf("set flag data '%s' %v", tagData.def, err)) // skip info and error parse
+	tagData.store = fv
+
+	if v, ok := fieldByName.Tag.Lookup(flagTag); ok && v != "" {
+		names := strings.Split(v, ",")
+		for k := range names {
+			names[k] = prefix.Flag + names[k]
+		}
+		fv.Flag = true
+		fv.Name = strings.Join(names, ",")
 
-		for _, fTag := range strings.Split(v, ",") {
+		for _, fTag := range names {
 			tagData.FlagSet.Var(fv, fTag, tagData.desc)
 			fl := tagData.FlagSet.Lookup(fTag)
 			tagData.Flags[fl.Name] = fl
@@ -149,21 +343,38 @@ func Fill[T any](field string, order ...order.Stages) Tag {
 		tagData.valid = v
 	}
 
-	tagData.ConfigFile = func(m map[string]any) Tag {
-		for k, v := range m {
-			if tagData.json == k {
+	if v, ok := fieldByName.Tag.Lookup(argTag); ok {
+		tagData.arg = v
+		fv.Arg = v
+		_, hasDefault := fieldByName.Tag.Lookup(defaultTag)
+		tagData.required = v != argRest && !hasDefault
+	}
+
+	// document - set the value of the config document (file or inline)
+	document := func(source string) func(map[string]any, string) Tag {
+		return func(m map[string]any, format string) Tag {
+			path := tagData.jsonPath
+			if v, ok := tagData.filePath[format]; ok {
+				path = v
+			}
+			if len(path) == 0 {
+				return tagData
+			}
+			if v, ok := lookupPath(m, path); ok {
+				value := tagData.store.setNative(source, formatKeys(v, tagData.store.Type.Type, format))
 				for _, f := range tagData.Flags {
-					f.DefValue = fmt.Sprintf("%v", v)
-					err := f.Value.Set(f.DefValue)
-					helpers.ToLog(err, fmt.Sprintf("set flag data '%s' error", f.DefValue))
+					f.DefValue = value
 				}
 			}
+			return tagData
 		}
-		return tagData
 	}
-	tagData.DummyFlags = func() Tag {
+	tagData.ConfigFile = document(SourceFile)
+	tagData.Inline = document(SourceInline)
+	tagData.Remote = document(SourceRemote)
+	tagData.DummyFlags = func(fs *flag.FlagSet) Tag {
 		for _, v := range os.Args {
-			if strings.Contains(v, testTrigger) {
+			if fs == flag.CommandLine && strings.Contains(v, testTrigger) {
 				var _ = func() bool {
 					testing.Init()
 					return true
@@ -172,66 +383,164 @@ func Fill[T any](field string, order ...order.Stages) Tag {
 			}
 		}
 		for _, f := range tagData.Flags {
-			if ff := flag.Lookup(f.Name); ff == nil {
-				flag.Var(f.Value, f.Name, f.Usage)
+			if ff := fs.Lookup(f.Name); ff == nil {
+				fs.Var(dummy{f.Value.(*storage)}, f.Name, f.Usage)
 			}
 		}
 		return tagData
 	}
-	tagData.Env = func() Tag {
-		env, ok := os.LookupEnv(tagData.env)
-		if ok {
-			for _, f := range tagData.Flags {
-				err := f.Value.Set(env)
-				helpers.ToLog(err, fmt.Sprintf("set flag data '%s' error", env))
-				break
-			}
+	tagData.Env = func(fsys fs.FS) Tag {
+		if tagData.env == "" {
+			return tagData
+		}
+		tagData.store.setEnv(SourceEnv, tagData.envFile, os.LookupEnv, fsys)
+		return tagData
+	}
+
+	tagData.DotEnv = func(m map[string]string, fsys fs.FS) Tag {
+		if tagData.env == "" {
+			return tagData
 		}
+		tagData.store.setEnv(SourceDotEnv, tagData.envFile, func(name string) (string, bool) {
+			v, ok := m[name]
+			return v, ok
+		}, fsys)
 		return tagData
 	}
 
-	tagData.Flag = func() Tag {
-		def := tagData.FlagSet.Output()
-		tagData.FlagSet.SetOutput(io.Discard)
-		for _, arg := range os.Args {
-			err := tagData.FlagSet.Parse([]string{arg})
-			helpers.ToLogWithType(err, helpers.LogNull)
+	// values - set the value by the key: the environment or the JSON key ("db.host")
+	values := func(source string) func(map[string]string) Tag {
+		return func(m map[string]string) Tag {
+			for _, name := range []string{tagData.env, tagData.json} {
+				if v, ok := m[name]; ok && name != "" {
+					err := tagData.store.setFrom(source, v)
+					helpers.ToLog(err, fmt.Sprintf("set flag data '%s' error", v))
+					break
+				}
+			}
+			return tagData
+		}
+	}
+	tagData.Dir = values(SourceDir)
+	tagData.KV = values(SourceKV)
+
+	tagData.Flag = func(args []Argument) Tag {
+		// repeated flags are appending to the slice and map
+		started := false
+		for _, arg := range args {
+			if _, ok := tagData.Flags[arg.Name]; ok {
+				if !started {
+					tagData.store.begin(SourceFlag)
+					started = true
+				}
+				err := tagData.store.Set(arg.Value)
+				helpers.ToLogWithType(err, helpers.LogNull)
+			}
 		}
-		tagData.FlagSet.SetOutput(def)
 		return tagData
 	}
 
-	flag.Usage = func() {
-		PrintDefaults(flag.CommandLine, order...)
+	tagData.Arg = func(positional []string, rest int) Tag {
+		switch {
+		case tagData.arg == "":
+		case tagData.arg == argRest:
+			if rest < len(positional) {
+				tagData.store.begin(SourceArg)
+				tagData.store.setSlice(positional[rest:])
+			}
+		default:
+			i, err := strconv.Atoi(tagData.arg)
+			if err != nil || i < 0 {
+				tagData.store.fail(SourceArg, fmt.Errorf("wrong index of the positional argument"), tagData.arg)
+				break
+			}
+			if i < len(positional) {
+				err = tagData.store.setFrom(SourceArg, positional[i])
+				helpers.ToLogWithType(err, helpers.LogNull)
+			}
+		}
+		return tagData
 	}
 
-	tagData.Valid = func() any {
-		var stringValueType any
-		var stringValueTypeString string
-		for _, f := range tagData.Flags {
-			stringValueType = f.Value.(*storage).Store
-			stringValueTypeString = f.Value.(*storage).StoreString
-			break
+	tagData.Valid = func(fsys fs.FS) any {
+		store := tagData.store
+		stringValueType := store.Store
+		stringValueTypeString := store.StoreString
+		// the value of the other source (env, file) is enough for the required positional argument
+		if tagData.required && (store.source == "" || store.source == SourceDefault) {
+			store.fail(SourceArg, ErrMissingArgument, "")
+		}
+		if tagData.valid == "" {
+			return stringValueType
 		}
+		found := false
 		for _, v := range validation.Valids {
 			if tagData.valid == fmt.Sprint(v) {
-				if ret, ok := v.Valid(stringValueTypeString, stringValueType); ok {
+				found = true
+				valid := v.Valid
+				if withFS, ok := v.(validation.FSValid); ok {
+					// files of the validation are reading from the file system of the loading
+					valid = func(stringValue string, value any) (any, bool) {
+						return withFS.ValidFS(fsys, stringValue, value)
+					}
+				}
+				if ret, ok := valid(stringValueTypeString, stringValueType); ok {
+					store.fail(SourceValid, nil, stringValueTypeString)
 					return ret
 				}
 			}
 
 		}
+		if found {
+			store.fail(SourceValid, fmt.Errorf("validation '%s' failed", tagData.valid), stringValueTypeString)
+		} else {
+			store.fail(SourceValid, fmt.Errorf("validation '%s' not exist", tagData.valid), stringValueTypeString)
+		}
 		return stringValueType
 	}
+	tagData.Errors = func() Errors {
+		var ret Errors
+		for _, err := range tagData.store.errs {
+			ret = append(ret, err)
+		}
+		ret.Sort()
+		return ret
+	}
 	return tagData
 }
 
+// String - current value of the field as string
+func (t Tag) String() string {
+	if t.store == nil {
+		return ""
+	}
+	return t.store.String()
+}
+
+// Value - current value of the field without validation
+func (t Tag) Value() any {
+	if t.store == nil {
+		return nil
+	}
+	return t.store.Store
+}
+
+// Source - source of the current value (default, file, env, flag...)
+func (t Tag) Source() string {
+	if t.store == nil {
+		return ""
+	}
+	return t.store.source
+}
+
 // PrintDefaults - printing help
-func PrintDefaults(f *flag.FlagSet, o ...order.Stages) {
+func PrintDefaults(f *flag.FlagSet, t Tags, o ...order.Stages) {
 	yellow := color.New(color.FgYellow).SprintfFunc()
 	red := color.New(color.FgRed).SprintfFunc()
 	cyan := color.New(color.FgCyan).SprintfFunc()
 
+	printUsage(f, t)
+
 	var def strings.Builder
 	def.WriteString("Order of priority for settings (low -> high): \n")
 	for k, v := range o {
@@ -242,17 +551,30 @@ func PrintDefaults(f *flag.FlagSet, o ...order.Stages) {
 		case order.FLAG:
 			def.WriteString("Flags")
 		case order.FILE:
-			def.WriteString("Config file (JSON)")
+			def.WriteString("Config file")
 		case order.ENV:
 			def.WriteString("Environment")
+		case order.DOTENV:
+			def.WriteString("Dotenv file")
+		case order.DIR:
+			def.WriteString("Config directory")
+		case order.INLINE:
+			def.WriteString("Inline config")
+		case order.REMOTE:
+			def.WriteString("Remote config")
+		case order.KV:
+			def.WriteString("Key/value sources")
 		}
 	}
 	_, err := fmt.Fprint(f.Output(), yellow("%s \n\n", def.String()))
 	helpers.ToLog(err, fmt.Sprintf("print data '%s' error", def.String()))
 	f.VisitAll(func(lf *flag.Flag) {
-		switch lf.Value.(type) {
+		var store *storage
+		switch v := lf.Value.(type) {
 		case *storage:
-			// dummy
+			store = v
+		case dummy:
+			store = v.storage
 		default:
 			return
 		}
@@ -273,7 +595,7 @@ func PrintDefaults(f *flag.FlagSet, o ...order.Stages) {
 			// for both 4- and 8-space tab stops.
 			b.WriteString("\n    \t")
 		}
-		vvv := comparatorInfo(lf.Value.(*storage), o...)
+		vvv := comparatorInfo(store, o...)
 		b.WriteString(strings.ReplaceAll(cyan("%s", usage), "\n", "\n    \t"))
 		b.WriteString("\n    \t")
 		b.WriteString(fmt.Sprintf("Default value: %v\n    \t", lf.DefValue))
@@ -283,41 +605,217 @@ func PrintDefaults(f *flag.FlagSet, o ...order.Stages) {
 	})
 }
 
+// printUsage - printing usage line with positional arguments
+func printUsage(f *flag.FlagSet, t Tags) {
+	cyan := color.New(color.FgCyan).SprintfFunc()
+	fileName, err := os.Executable()
+	if err != nil {
+		fileName = "appImageBinary"
+	} else {
+		fileName = filepath.Base(fileName)
+	}
+	var args []Tag
+	for _, v := range t {
+		if v.arg != "" {
+			args = append(args, v)
+		}
+	}
+	// indexes first, then rest
+	sort.Slice(args, func(i, j int) bool {
+		if args[i].arg == argRest || args[j].arg == argRest {
+			return args[j].arg == argRest && args[i].arg != argRest
+		}
+		a, _ := strconv.Atoi(args[i].arg)
+		b, _ := strconv.Atoi(args[j].arg)
+		return a < b
+	})
+	if f != flag.CommandLine && f.Name() != "" {
+		// subcommand
+		fileName += " " + f.Name()
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Usage: %s [flags]", fileName))
+	for _, v := range args {
+		b.WriteString(" " + v.argName())
+	}
+	b.WriteString("\n")
+	for _, v := range args {
+		b.WriteString(fmt.Sprintf("  %s\n    \t%s\n", v.argName(), cyan("%s", v.desc)))
+	}
+	_, err = fmt.Fprint(f.Output(), b.String(), "\n")
+	helpers.ToLog(err, "print data error")
+}
+
+// argName - name of the positional argument for the usage
+func (t Tag) argName() string {
+	name := t.json
+	if name == "" {
+		name = strings.ToLower(t.Name)
+	}
+	if t.arg == argRest {
+		return "[" + name + "...]"
+	}
+	if !t.required {
+		return "[" + name + "]"
+	}
+	return "<" + name + ">"
+}
+
 func comparatorStringType(flagType flag.Value, reflectType reflect.StructField, stringValue any, onlyForMarshaller bool) (any, error) {
-	reflectTypeName := strings.ToLower(strings.TrimSpace(reflectType.Type.Name()))
-	switch reflectTypeName {
-	case "string":
-		return stringValue, nil
-	case "bool":
-		return helpers.ValidBool(fmt.Sprintf("%v", stringValue)), nil
-	case "duration":
-		return helpers.ValidDuration(fmt.Sprintf("%v", stringValue)), nil
-	case "int8", "int16", "int32", "int64", "rune":
-		return helpers.ValidInt(fmt.Sprintf("%v", stringValue)), nil
-	case "int":
-		return int(helpers.ValidInt(fmt.Sprintf("%v", stringValue))), nil
-	case "uint8", "uint16", "uint32", "uint64":
-		return helpers.ValidUint(fmt.Sprintf("%v", stringValue)), nil
-	case "uint":
-		return uint(helpers.ValidUint(fmt.Sprintf("%v", stringValue))), nil
-	case "float32", "float64":
-		return helpers.ValidFloat(fmt.Sprintf("%v", stringValue)), nil
+	typ := reflectType.Type
+	value := fmt.Sprintf("%v", stringValue)
+	ret := reflect.New(typ).Elem()
+	// empty value - zero value of the type
+	if value == "" {
+		return ret.Interface(), nil
+	}
+	if isDuration(typ) {
+		v, err := helpers.ParseDuration(value)
+		if err != nil {
+			return ret.Interface(), err
+		}
+		ret.SetInt(int64(v))
+		return ret.Interface(), nil
+	}
+	if method, ok := reflect.PointerTo(typ).MethodByName("UnmarshalText"); ok {
+		in := make([]reflect.Value, method.Type.NumIn())
+		yyy := reflect.New(typ).Interface()
+		in[0] = reflect.ValueOf(yyy)
+		in[1] = reflect.ValueOf([]byte(value))
+		if err, _ := method.Func.Call(in)[0].Interface().(error); err != nil {
+			return in[0].Elem().Interface(), err
+		}
+		return in[0].Elem().Interface(), nil
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		ret.SetString(value)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return ret.Interface(), err
+		}
+		ret.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(value, 10, typ.Bits())
+		if err != nil {
+			return ret.Interface(), err
+		}
+		ret.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, err := strconv.ParseUint(value, 10, typ.Bits())
+		if err != nil {
+			return ret.Interface(), err
+		}
+		ret.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(value, typ.Bits())
+		if err != nil {
+			return ret.Interface(), err
+		}
+		ret.SetFloat(v)
 	default:
-		if method, ok := reflect.PointerTo(reflectType.Type).MethodByName("UnmarshalText"); ok {
-			in := make([]reflect.Value, method.Type.NumIn())
-			yyy := reflect.New(reflectType.Type).Interface()
-			in[0] = reflect.ValueOf(yyy)
-			in[1] = reflect.ValueOf([]byte(fmt.Sprintf("%v", stringValue)))
-			method.Func.Call(in)[0].Interface()
-			return in[0].Elem().Interface(), nil
+		return ret.Interface(), errors.New("parse error")
+	}
+	return ret.Interface(), nil
+}
+
+// Convert - convert the value (result of the validation) to the type of the field.
+// Numbers are checking for overflow
+func Convert(value any, typ reflect.Type) (reflect.Value, error) {
+	v := reflect.ValueOf(value)
+	if v.Type().AssignableTo(typ) {
+		return v, nil
+	}
+	ret := reflect.New(typ).Elem()
+	switch {
+	case isInt(v.Kind()) && isInt(typ.Kind()):
+		if ret.OverflowInt(v.Int()) {
+			return ret, fmt.Errorf("value %v overflows %s", value, typ)
+		}
+		ret.SetInt(v.Int())
+	case isUint(v.Kind()) && isUint(typ.Kind()):
+		if ret.OverflowUint(v.Uint()) {
+			return ret, fmt.Errorf("value %v overflows %s", value, typ)
 		}
-		yyy := reflect.New(reflectType.Type).Interface()
-		return reflect.ValueOf(yyy).Elem().Interface(), errors.New("parse error")
+		ret.SetUint(v.Uint())
+	case isInt(v.Kind()) && isUint(typ.Kind()):
+		if v.Int() < 0 || ret.OverflowUint(uint64(v.Int())) {
+			return ret, fmt.Errorf("value %v overflows %s", value, typ)
+		}
+		ret.SetUint(uint64(v.Int()))
+	case isUint(v.Kind()) && isInt(typ.Kind()):
+		if v.Uint() > math.MaxInt64 || ret.OverflowInt(int64(v.Uint())) {
+			return ret, fmt.Errorf("value %v overflows %s", value, typ)
+		}
+		ret.SetInt(int64(v.Uint()))
+	case isFloat(v.Kind()) && isFloat(typ.Kind()):
+		if ret.OverflowFloat(v.Float()) {
+			return ret, fmt.Errorf("value %v overflows %s", value, typ)
+		}
+		ret.SetFloat(v.Float())
+	case v.Type().ConvertibleTo(typ) && v.Kind() == typ.Kind():
+		ret.Set(v.Convert(typ))
+	default:
+		return ret, fmt.Errorf("type %T can not be set to %s", value, typ)
 	}
+	return ret, nil
+}
+
+func isJSONUnmarshaler(typ reflect.Type) bool {
+	return reflect.PointerTo(typ).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem())
+}
+
+// isScalar - decoded value of the config file is not the object or the array
+func isScalar(value any) bool {
+	switch value.(type) {
+	case map[string]any, []any:
+		return false
+	}
+	return true
+}
+
+func isDuration(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Int64 && (typ == reflect.TypeOf(time.Duration(0)) || strings.ToLower(typ.Name()) == "duration")
+}
+
+func isInt(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUint(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func isText(typ reflect.Type) bool {
+	_, ok := reflect.PointerTo(typ).MethodByName("UnmarshalText")
+	return ok
+}
+
+// typeName - name of the type for the help samples
+func typeName(typ reflect.Type) string {
+	if isDuration(typ) {
+		return "duration"
+	}
+	if isText(typ) || (typ.Kind() == reflect.Slice && !isCollection(typ)) {
+		return ""
+	}
+	return typ.Kind().String()
 }
 
 func comparatorInfo(t *storage, o ...order.Stages) string {
-	tt := t.Type.Type.Name()
 	fileName, err := os.Executable()
 	if err != nil {
 		fileName = "appImageBinary"
@@ -325,7 +823,7 @@ func comparatorInfo(t *storage, o ...order.Stages) string {
 		fileName = filepath.Base(fileName)
 	}
 	ret := ""
-	reflectTypeName := strings.ToLower(strings.TrimSpace(tt))
+	reflectTypeName := typeName(t.Type.Type)
 	for _, v := range o {
 		switch reflectTypeName {
 		case "string":
@@ -333,7 +831,7 @@ func comparatorInfo(t *storage, o ...order.Stages) string {
 			case order.FLAG:
 				ret += sample(fileName, t.Name, t.Default)
 			case order.FILE:
-				ret += sampleJson(t.JSON, t.Store)
+				ret += sampleJson(t.JSONPath, t.Store)
 			case order.ENV:
 				ret += sampleEnv(t.Env, t.Default)
 			}
@@ -342,7 +840,7 @@ func comparatorInfo(t *storage, o ...order.Stages) string {
 			case order.FLAG:
 				ret += sampleBool(fileName, t.Name)
 			case order.FILE:
-				ret += sampleJson(t.JSON, t.Store)
+				ret += sampleJson(t.JSONPath, t.Store)
 			case order.ENV:
 				ret += sampleEnv(t.Env, t.Default)
 			}
@@ -351,7 +849,7 @@ func comparatorInfo(t *storage, o ...order.Stages) string {
 			case order.FLAG:
 				ret += durationSample(fileName, t.Name, t.Default)
 			case order.FILE:
-				ret += sampleJson(t.JSON, t.Store)
+				ret += sampleJson(t.JSONPath, t.Store)
 			case order.ENV:
 				ret += sampleEnv(t.Env, t.Default)
 			}
@@ -360,7 +858,7 @@ func comparatorInfo(t *storage, o ...order.Stages) string {
 			case order.FLAG:
 				ret += sample(fileName, t.Name, t.Default)
 			case order.FILE:
-				ret += sampleJson(t.JSON, t.Store)
+				ret += sampleJson(t.JSONPath, t.Store)
 			case order.ENV:
 				ret += sampleEnv(t.Env, t.Default)
 			}
@@ -369,16 +867,16 @@ func comparatorInfo(t *storage, o ...order.Stages) string {
 			case order.FLAG:
 				ret += sample(fileName, t.Name, t.Default)
 			case order.FILE:
-				ret += sampleJson(t.JSON, t.Store)
+				ret += sampleJson(t.JSONPath, t.Store)
 			case order.ENV:
 				ret += sampleEnv(t.Env, t.Default)
 			}
-		case "uint8", "uint16", "uint32", "uint64":
+		case "uint8", "uint16", "uint32", "uint64", "uintptr":
 			switch v {
 			case order.FLAG:
 				ret += sample(fileName, t.Name, t.Default)
 			case order.FILE:
-				ret += sampleJson(t.JSON, t.Store)
+				ret += sampleJson(t.JSONPath, t.Store)
 			case order.ENV:
 				ret += sampleEnv(t.Env, t.Default)
 			}
@@ -387,7 +885,7 @@ func comparatorInfo(t *storage, o ...order.Stages) string {
 			case order.FLAG:
 				ret += sample(fileName, t.Name, t.Default)
 			case order.FILE:
-				ret += sampleJson(t.JSON, t.Store)
+				ret += sampleJson(t.JSONPath, t.Store)
 			case order.ENV:
 				ret += sampleEnv(t.Env, t.Default)
 			}
@@ -396,7 +894,16 @@ func comparatorInfo(t *storage, o ...order.Stages) string {
 			case order.FLAG:
 				ret += sample(fileName, t.Name, fmt.Sprintf("%f", helpers.ValidFloat(t.Default)))
 			case order.FILE:
-				ret += sampleJson(t.JSON, t.Store)
+				ret += sampleJson(t.JSONPath, t.Store)
+			case order.ENV:
+				ret += sampleEnv(t.Env, t.Default)
+			}
+		case "slice", "map":
+			switch v {
+			case order.FLAG:
+				ret += sampleCollection(fileName, t.Name, t.Default, t.Sep)
+			case order.FILE:
+				ret += sampleJson(t.JSONPath, t.Store)
 			case order.ENV:
 				ret += sampleEnv(t.Env, t.Default)
 			}
@@ -405,7 +912,7 @@ func comparatorInfo(t *storage, o ...order.Stages) string {
 			case order.FLAG:
 				// ret += sample(fileName, flagName, def, flagOrder)
 			case order.FILE:
-				ret += sampleJson(t.JSON, t.Store)
+				ret += sampleJson(t.JSONPath, t.Store)
 			case order.ENV:
 				ret += sampleEnv(t.Env, t.Default)
 			}
@@ -418,9 +925,15 @@ func sampleEnv(envValue, def string) string {
 	return fmt.Sprintf("Sample environment:\t%s=%s\n", strings.ToUpper(envValue), def)
 }
 
-func sampleJson(jsonValue string, def any) string {
+func sampleJson(jsonPath []string, def any) string {
+	if len(jsonPath) == 0 {
+		return ""
+	}
 	m := make(map[string]any, 1)
-	m[jsonValue] = def
+	m[jsonPath[len(jsonPath)-1]] = def
+	for k := len(jsonPath) - 2; k >= 0; k-- {
+		m = map[string]any{jsonPath[k]: m}
+	}
 	out, err := json.MarshalIndent(m, "", "  ")
 	if err != nil {
 		return ""
@@ -432,6 +945,21 @@ func sample(fileName, flagName, def string) string {
 	return fmt.Sprintf("Sample flag value:\t%s -%s=%s\n", fileName, flagName, def)
 }
 
+func sampleCollection(fileName, flagName, def, sep string) string {
+	if sep == "" {
+		sep = defaultSep
+	}
+	ret := sample(fileName, flagName, def)
+	if items := strings.Split(def, sep); len(items) > 1 {
+		ret += fmt.Sprintf("Sample(repeated):\t%s", fileName)
+		for _, v := range items {
+			ret += fmt.Sprintf(" -%s=%s", flagName, v)
+		}
+		ret += "\n"
+	}
+	return ret
+}
+
 func sampleBool(fileName, flagName string) string {
 	return fmt.Sprintf("Sample(TRUE):\t%s -%s\n", fileName, flagName) +
 		fmt.Sprintf("Sample(default):\t%s\n", fileName) +
@@ -1,6 +1,10 @@
 package validation
 
-import "github.com/KusoKaihatsuSha/startup/internal/helpers"
+import (
+	"io/fs"
+
+	"github.com/KusoKaihatsuSha/startup/internal/helpers"
+)
 
 var (
 	Valids []Valid
@@ -10,6 +14,11 @@ type Valid interface {
 	Valid(string, any) (any, bool)
 }
 
+// FSValid - validation with the files. The files are reading from the file system of the loading (startup.FS)
+type FSValid interface {
+	ValidFS(fs.FS, string, any) (any, bool)
+}
+
 // Add - add custom validation. Example in interface docs.
 func Add(value ...Valid) {
 	Valids = append(Valids, value...)
@@ -55,9 +64,28 @@ func init() {
 	)
 }
 
+// IsDefault - the validation is one of the default validations
+func IsDefault(name string) bool {
+	switch name {
+	case string(defFileConfigValidation), string(tmpFileValidation), string(fileValidation), string(urlValidation),
+		string(boolValidation), string(intValidation), string(floatValidation), string(durationValidation),
+		string(uuidValidation):
+		return true
+	}
+	return false
+}
+
 // Valid Implements default validations
 func (o defFileConfigValid) Valid(stringValue string, value any) (any, bool) {
-	return helpers.ValidConfigFile(value.(string)), true
+	return o.ValidFS(helpers.OS, stringValue, value)
+}
+
+// ValidFS Implements default validations with the file system of the loading
+func (o defFileConfigValid) ValidFS(fsys fs.FS, stringValue string, value any) (any, bool) {
+	if files, ok := value.([]string); ok {
+		return helpers.ValidConfigFiles(fsys, files), true
+	}
+	return helpers.ValidConfigFile(fsys, value.(string)), true
 }
 
 // Valid Implements default validations
@@ -0,0 +1,115 @@
+package startup
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KusoKaihatsuSha/startup/internal/helpers"
+	tags "github.com/KusoKaihatsuSha/startup/internal/tag"
+)
+
+/*
+Source - key/value store of the KV stage (Consul, etcd).
+List returns the values of the keys under the prefix. Keys are without the prefix:
+'db/host' and 'db.host' are the JSON key "db.host", 'DB_HOST' is the environment name of the field.
+*/
+type Source interface {
+	List(prefix string) (map[string]string, error)
+}
+
+// kvSource - registered source with the prefix
+type kvSource struct {
+	prefix string
+	source Source
+}
+
+var (
+	sourcesMu sync.Mutex
+	sources   []kvSource
+)
+
+// AddSource - register the source of the KV stage. Sources are applying in the order of the registration,
+// the value of the last source wins
+func AddSource(prefix string, source Source) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	sources = append(sources, kvSource{prefix: prefix, source: source})
+}
+
+// ResetSources - remove all sources of the KV stage
+func ResetSources() {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	sources = nil
+}
+
+// MemorySource - source of the KV stage in the memory. Keys are with the prefix ("app/db/host")
+type MemorySource map[string]string
+
+// List - values of the keys under the prefix
+func (m MemorySource) List(prefix string) (map[string]string, error) {
+	ret := make(map[string]string)
+	for k, v := range m {
+		if strings.HasPrefix(k, prefix) {
+			ret[strings.TrimPrefix(k, prefix)] = v
+		}
+	}
+	return ret, nil
+}
+
+// ConsulSource - source of the KV stage with the Consul-style HTTP API ('GET /v1/kv/<prefix>?recurse=true')
+type ConsulSource struct {
+	// Address - address of the agent ("http://127.0.0.1:8500")
+	Address string
+	// Token - ACL token. Empty - without authorization
+	Token string
+	// Timeout - timeout of the request. Zero - without timeout
+	Timeout time.Duration
+	// Client - HTTP client. Nil - http.DefaultClient
+	Client *http.Client
+}
+
+// List - values of the keys under the prefix. Missing prefix is not the error
+func (c ConsulSource) List(prefix string) (map[string]string, error) {
+	return helpers.FetchConsul(helpers.Consul{
+		Address: c.Address,
+		Token:   c.Token,
+		Timeout: c.Timeout,
+		Client:  c.Client,
+	}, prefix)
+}
+
+// kvKey - key of the source as the JSON key ("db/host" -> "db.host")
+func kvKey(key string) string {
+	return strings.ReplaceAll(strings.Trim(key, "/"), "/", ".")
+}
+
+// kv get info from the key/value sources
+func (t *temp[T]) kv() *temp[T] {
+	sourcesMu.Lock()
+	list := append([]kvSource(nil), sources...)
+	sourcesMu.Unlock()
+	for _, s := range list {
+		values, err := s.source.List(s.prefix)
+		if err != nil {
+			helpers.ToLog(err, fmt.Sprintf("kv source '%s' error", s.prefix))
+			t.errs = append(t.errs, &tags.FieldError{
+				Source: tags.SourceKV,
+				Name:   s.prefix,
+				Err:    err,
+			})
+			continue
+		}
+		m := make(map[string]string, len(values))
+		for k, v := range values {
+			m[kvKey(k)] = v
+		}
+		for _, v := range t.Tags {
+			v.KV(m)
+		}
+	}
+	return t
+}
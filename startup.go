@@ -1,13 +1,20 @@
 package startup
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
+	"net/http"
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/fatih/color"
 
 	"github.com/KusoKaihatsuSha/startup/internal/helpers"
 	"github.com/KusoKaihatsuSha/startup/internal/order"
@@ -16,23 +23,159 @@ import (
 	"github.com/KusoKaihatsuSha/startup/internal/validation"
 )
 
+// loaded - memoized results of Get. Key is the type of the struct and the stages
+var loaded sync.Map
+
+// loadMu - loading is using os.Args and flag.CommandLine. One loading at a time
+var loadMu sync.Mutex
+
+// arguments - positional arguments of the last loading
+var arguments []string
+
+// loadedKey - key of the memoized result
+type loadedKey struct {
+	typ    reflect.Type
+	stages string
+}
+
+// loadedValue - memoized result
+type loadedValue struct {
+	once  sync.Once
+	value any
+}
+
+var DEBUG = false
+
+// DotEnvFile - filepath of the dotenv file for the DOTENV stage
+var DotEnvFile = ".env"
+
+// Reserved field of the config file. Empty ConfigFlag, ConfigEnv and ConfigDefault disable the config file
 var (
-	this      any
-	onceFlags = sync.Once{}
+	// ConfigFlag - flag with the filepath of the config file. Empty - no flag
+	ConfigFlag = "config"
+	// ConfigEnv - environment with the filepath of the config file. Empty - no environment
+	ConfigEnv = "CONFIG"
+	// ConfigDefault - default filepath of the config file. Empty - no default file and no search
+	ConfigDefault = "config.ini"
+	// ConfigJSON - key of the filepath inside the config file. Empty - no key
+	ConfigJSON = "startup_configuration_file"
+	// ConfigInlineEnv - environment with the config document for the INLINE stage
+	ConfigInlineEnv = "CONFIG_CONTENT"
 )
 
-var DEBUG = false
+// AppName - name of the application for the search paths of the config file. Empty - name of the executable
+var AppName = ""
+
+// ConfigSearchPaths - directories for the config file if '-config' and 'CONFIG' are not set.
+// Nil - working directory, $XDG_CONFIG_HOME/<AppName>, ~/.config/<AppName>, /etc/<AppName>, directory of the executable
+var ConfigSearchPaths []string
+
+// ConfigSearchAll - merge the config files of all search paths, the next overrides the previous one.
+// Default paths are merging from the directory of the executable to the working directory.
+// False - only the files of the first path
+var ConfigSearchAll = false
+
+// FS - file system for the config files, the dotenv file, the directory of DIR stage and the secrets of EnvFromFile.
+// Nil - file system of the OS. Names are slash separated for the other file systems (fstest.MapFS)
+var FS fs.FS
+
+/*
+BaseConfigFS - file system with the base config files (embed.FS). BaseConfigFiles are the names or the glob patterns inside.
+Base files are applying in the FILE stage before the config files of '-config':
+
+	//go:embed defaults.yaml
+	var defaults embed.FS
+
+	startup.BaseConfigFS = defaults
+	startup.BaseConfigFiles = []string{"defaults.yaml"}
+*/
+var (
+	BaseConfigFS    fs.FS
+	BaseConfigFiles []string
+)
+
+// RemoteOptions - settings of the REMOTE stage
+type RemoteOptions struct {
+	// URL - endpoint of the config document. Empty - the stage is skipped
+	URL string
+	// Timeout - timeout of one attempt
+	Timeout time.Duration
+	// Retries - count of the repeats after the network error or 5xx status
+	Retries int
+	// TokenEnv - environment with the bearer token. Empty - without authorization
+	TokenEnv string
+	// CacheFile - last successful response. Empty - <user cache dir>/<AppName>/remote-config, "-" - without cache
+	CacheFile string
+	// Client - HTTP client. Nil - http.DefaultClient
+	Client *http.Client
+}
+
+// Remote - settings of the REMOTE stage
+var Remote = RemoteOptions{
+	Timeout:  10 * time.Second,
+	Retries:  2,
+	TokenEnv: "CONFIG_TOKEN",
+}
+
+// ConfigDir - directory for the DIR stage
+var ConfigDir = "/etc/config"
+
+// FORMAT - format of the config file for all loadings (FormatJSON, FormatYAML, FormatINI, FormatTOML).
+// Empty - by the extension of the file: '.yaml' and '.yml' are YAML, '.ini' is INI, '.toml' is TOML, other are JSON
+var FORMAT = ""
+
+const (
+	// FormatJSON - config file is JSON
+	FormatJSON = helpers.FormatJSON
+
+	// FormatYAML - config file is YAML. Keys are from the 'yaml' tag or from the 'json' tag if not exist
+	FormatYAML = helpers.FormatYAML
+
+	// FormatINI - config file is INI. Sections and dotted keys are the nested structs. Keys are from the 'json' tag
+	FormatINI = helpers.FormatINI
+
+	// FormatTOML - config file is TOML. Keys are from the 'toml' tag or from the 'json' tag if not exist
+	FormatTOML = helpers.FormatTOML
+)
 
 const (
 	// FLAG Get data from flags
 	FLAG = order.FLAG
 
-	// FILE Get data from json file
+	// FILE Get data from config file (JSON, YAML, INI, TOML)
 	FILE = order.FILE
 
 	// ENV Get data from environments
 	ENV = order.ENV
 
+	// DOTENV Get data from dotenv file (DotEnvFile). Keys are from the 'env' tag.
+	// The environment of the process is not changing
+	DOTENV = order.DOTENV
+
+	// DIR Get data from directory (ConfigDir) with file per value (Kubernetes ConfigMap).
+	// Name of the file is the 'env' or the 'json' name of the field
+	DIR = order.DIR
+
+	// INLINE Get data from config document (JSON or YAML) inside the environment ConfigInlineEnv.
+	// Document is applying same as the config file
+	INLINE = order.INLINE
+
+	// REMOTE Get data from config document (JSON, YAML, TOML) of HTTP(S) endpoint (Remote).
+	// Last successful response is cached on the disk and is using if the endpoint is not available or the document is broken
+	REMOTE = order.REMOTE
+
+	// KV Get data from key/value sources (AddSource): in-memory, Consul-style HTTP API.
+	// Keys are the 'env' or the 'json' name of the field, "/" is the same as "."
+	KV = order.KV
+
+	// RequireConfig - Config file of the FILE stage is mandatory: missing, unreadable or broken file is the error of Load.
+	// Get and GetForce print the error and exit with code 1. RequireConfig without the FILE stage is the error ErrRequireConfigStage
+	RequireConfig = order.RequireConfig
+
+	// EnvFromFile - Get value of the environment (and the dotenv file) from the file of <ENV>_FILE:
+	// DB_PASSWORD_FILE=/run/secrets/db. Tag 'envfile:"true|false"' enables or disables it for the field
+	EnvFromFile = order.EnvFromFile
+
 	// PreloadConfigEnvThenFlag - Get filepath config from environments and then flags
 	// Default
 	PreloadConfigEnvThenFlag = order.PreloadConfigEnvThenFlag
@@ -46,7 +189,7 @@ const (
 	// PreloadConfigEnv - Get filepath config from environments
 	PreloadConfigEnv = order.PreloadConfigEnv
 
-	// NoPreloadConfig - Get filepath config file only from ordered stages
+	// NoPreloadConfig - Get filepath config file only from ordered stages. DIR, REMOTE and KV are not using for it
 	NoPreloadConfig = order.NoPreloadConfig
 )
 
@@ -56,15 +199,61 @@ type temp[T any] struct {
 	tags.Tags
 	CustomerConfiguration T
 	Configuration         configuration
+	errs                  tags.Errors
+	searched              bool
+	fsys                  fs.FS
+	args                  []string
+	input                 []string
+	flagSet               *flag.FlagSet
 }
 
+// FieldError describes one value that could not be set to the field:
+// the field, the source of the value (default, file, env, flag, valid) and the reason.
+type FieldError = tags.FieldError
+
+// Errors is the error returned by Load. It lists every FieldError of the loading.
+type Errors = tags.Errors
+
+// ErrConfigNotFound is the reason of FieldError when the config file is required (RequireConfig) and not found.
+var ErrConfigNotFound = errors.New("config file is not found")
+
+// ErrRequireConfigStage is the reason of FieldError when RequireConfig is set without the FILE stage.
+var ErrRequireConfigStage = errors.New("RequireConfig is set without FILE stage")
+
+// ErrMissingArgument is the reason of FieldError when the positional argument by index ('arg:"0"')
+// without 'default' tag is not set by any source.
+var ErrMissingArgument = tags.ErrMissingArgument
+
+// ErrEnvConflict is the reason of FieldError when both <ENV> and <ENV>_FILE environments are set.
+var ErrEnvConflict = tags.ErrEnvConflict
+
 /*
 Configuration consists of settings that are filled in at startup.
 Default fields:
-  - "Config" - filepath for config file
+  - "Config" - filepaths for config files. Repeated flag, list ("a.json,b.yaml"), glob ("conf.d/*.json") or directory.
+    Files are merging in order, the next file overrides the previous one.
+    Names are from ConfigFlag, ConfigEnv, ConfigDefault and ConfigJSON (see configField)
 */
 type configuration struct {
-	Config string `json:"startup_configuration_file" default:"config.ini" flag:"config" env:"CONFIG" help:"Configuration settings file" valid:"default_configuration_file"`
+	Config []string
+}
+
+// configKey - key of the reserved field in the tags. Not the path of the field, the field "Config" of the customer struct is not overridden
+const configKey = "startup.Config"
+
+// configField - field "Config" of the configuration with the tags of the current names
+func configField() reflect.StructField {
+	field, _ := reflect.TypeOf(configuration{}).FieldByName("Config")
+	field.Tag = reflect.StructTag(fmt.Sprintf(
+		`json:%q default:%q flag:%q env:%q help:"Configuration settings files" valid:"default_configuration_file"`,
+		ConfigJSON, ConfigDefault, ConfigFlag, ConfigEnv,
+	))
+	return field
+}
+
+// configDisabled - config file is not using
+func configDisabled() bool {
+	return ConfigFlag == "" && ConfigEnv == "" && ConfigDefault == ""
 }
 
 /*
@@ -96,7 +285,11 @@ Example:
 			fmt.Println(configurations)
 		}
 
+Validation with the method ValidFS(fs.FS, string, any) (any, bool) is called instead of Valid
+with the file system of the loading (startup.FS).
+
 Default validations:
+  - `default_configuration_file` - Check exist the config file inside startup.FS (string or []string in struct)
   - `tmp_file` - Check exist inside Temp folder and create if not exist  (string in struct)
   - `file` - Check exist the filepath and create if not exist (string in struct)
   - `url` - Check url is correct (string in struct)
@@ -107,8 +300,8 @@ Default validations:
   - `uuid` - Check uuid. Return new if not exist (string in struct)
 
 Caution:
-flags are reserved:
-  - config
+the flag of the config file is reserved:
+  - ConfigFlag ('config' by default)
 */
 func AddValidation(value ...validation.Valid) {
 	validation.Add(value...)
@@ -119,9 +312,14 @@ GetForce will initialize scan the flags, environment and config-file with the ri
   - order.FLAG - flag
   - order.FILE - config file
   - order.ENV - environment
-
-Caution! flags are reserved:
-  - config
+  - order.DOTENV - dotenv file
+  - order.DIR - directory with file per value
+  - order.INLINE - config document inside the environment
+  - order.REMOTE - config document of HTTP(S) endpoint
+  - order.KV - key/value sources
+
+Caution! the flag of the config file is reserved:
+  - ConfigFlag ('config' by default)
 */
 func GetForce[T any](stages ...order.Stages) T {
 	// ---debug---
@@ -130,28 +328,61 @@ func GetForce[T any](stages ...order.Stages) T {
 	}
 	// ---debug---
 
-	return get[T](stages...).CustomerConfiguration
+	return required(get[T](stages...)).CustomerConfiguration
 }
 
-func (t *temp[T]) prepare(config tags.Tags) *temp[T] {
-	elements := reflect.ValueOf(&t.CustomerConfiguration).Elem()
-	t.Tags = make(tags.Tags, elements.NumField())
-	for ii := 0; ii < elements.NumField(); ii++ {
-		name := elements.Type().Field(ii).Name
-		t.Tags[name] = tags.Fill[T](name, t.Stages...)
+/*
+Load works as GetForce, but returns the error with every field that failed.
+The error is Errors and lists the field, the source of the value and the reason:
+  - unreadable or broken config file
+  - value which can not be parsed to the type of the field
+  - failed validation
+
+Example:
+
+	configurations, err := startup.Load[Test](startup.FILE, startup.ENV, startup.FLAG)
+	if err != nil {
+		log.Fatal(err)
 	}
+
+Caution! the flag of the config file is reserved:
+  - ConfigFlag ('config' by default)
+*/
+func Load[T any](stages ...order.Stages) (T, error) {
+	// ---debug---
+	if DEBUG {
+		helpers.PrintDebug(stages...)
+	}
+	// ---debug---
+
+	load := get[T](stages...)
+	return load.CustomerConfiguration, load.err()
+}
+
+func (t *temp[T]) prepare(config tags.Tags) *temp[T] {
+	t.Tags = tags.Struct[T](t.Stages...)
 	for configTagName, configTagData := range config {
 		t.Tags[configTagName] = configTagData
 	}
+	usage := func() {
+		tags.PrintDefaults(t.flagSet, t.Tags, t.Stages...)
+		if slices.Contains(t.Stages, order.FILE) && !configDisabled() {
+			printConfigFiles(t.flagSet, t.Configuration.Config, t.searched)
+		}
+		printCommands(t.flagSet)
+	}
+	if t.flagSet == flag.CommandLine {
+		flag.Usage = usage
+	} else {
+		t.flagSet.Usage = usage
+	}
 	return t
 }
 
 func (t *temp[T]) preparePreload() *temp[T] {
-	elements := reflect.ValueOf(&t.Configuration).Elem()
-	t.Tags = make(tags.Tags, elements.NumField())
-	for ii := 0; ii < elements.NumField(); ii++ {
-		name := elements.Type().Field(ii).Name
-		t.Tags[name] = tags.Fill[configuration](name, t.Stages...)
+	t.Tags = make(tags.Tags)
+	if !configDisabled() {
+		t.Tags[configKey] = tags.Fill(configKey, configField(), tags.Prefix{}, t.Stages...)
 	}
 	return t
 }
@@ -160,36 +391,39 @@ func (t *temp[T]) fillPreload(fileExist bool) *temp[T] {
 	for _, v := range t.Stages {
 		switch v {
 		case order.FLAG:
+			t.flagNoParse()
+
 			// ---debug---
 			if DEBUG && fileExist {
-				printing := ""
-				for _, arg := range os.Args {
-					if strings.Contains(arg, "-config=") {
-						printing = "\tinfo about config file:\tGet filepath from flag '-config'"
+				printing := fmt.Sprintf("\tinfo about config file:\tFlag '-%s' with filepath not set", ConfigFlag)
+				flags, _ := tags.ParseArgs(t.input, t.Tags)
+				for _, arg := range flags {
+					if arg.Name == ConfigFlag {
+						printing = fmt.Sprintf("\tinfo about config file:\tGet filepath from flag '-%s'", ConfigFlag)
 						break
-					} else {
-						printing = "\tinfo about config file:\tFlag '-config' with filepath not set"
 					}
 				}
 				fmt.Println(printing)
 			}
 			// ---debug---
-
-			t.flagNoParse()
 		case order.FILE:
 			t.conf()
 		case order.ENV:
 			// ---debug---
 			if DEBUG && fileExist {
-				if os.Getenv("CONFIG") != "" {
-					fmt.Println("\tinfo about config file:\tGet filepath from environment 'CONFIG'")
+				if os.Getenv(ConfigEnv) != "" {
+					fmt.Printf("\tinfo about config file:\tGet filepath from environment '%s'\n", ConfigEnv)
 				} else {
-					fmt.Println("\tinfo about config file:\tEnvironment 'CONFIG' with filepath not set")
+					fmt.Printf("\tinfo about config file:\tEnvironment '%s' with filepath not set\n", ConfigEnv)
 				}
 			}
 			// ---debug---
 
 			t.env()
+		case order.DOTENV:
+			t.dotenv()
+		case order.INLINE:
+			t.inline()
 		}
 	}
 	return t
@@ -204,17 +438,45 @@ func (t *temp[T]) fill() *temp[T] {
 			t.conf()
 		case order.ENV:
 			t.env()
+		case order.DOTENV:
+			t.dotenv()
+		case order.DIR:
+			t.dir()
+		case order.INLINE:
+			t.inline()
+		case order.REMOTE:
+			t.remote()
+		case order.KV:
+			t.kv()
 		}
 	}
+	if !slices.Contains(t.Stages, order.FLAG) {
+		// fields with 'arg' tag are binding without the FLAG stage too
+		t.positional()
+	}
 	return t
 }
 
 func get[T any](stages ...order.Stages) temp[T] {
+	return getWith[T](rootArgs[T](stages...), flag.CommandLine, stages...)
+}
+
+// getWith - loading with the command line arguments (without the program name) and the flag set for the help
+func getWith[T any](input []string, flagSet *flag.FlagSet, stages ...order.Stages) temp[T] {
+	loadMu.Lock()
+	defer loadMu.Unlock()
+	fsys := helpers.OS
+	if FS != nil {
+		fsys = FS
+	}
 	fileExistInStages := helpers.FileConfExistInStages(stages...)
 	preload := (&temp[T]{
 		Stages:                helpers.PresetPreload(stages...),
 		CustomerConfiguration: *new(T),
 		Configuration:         configuration{},
+		input:                 input,
+		flagSet:               flagSet,
+		fsys:                  fsys,
 	}).
 		preparePreload().
 		fillPreload(fileExistInStages).
@@ -222,25 +484,35 @@ func get[T any](stages ...order.Stages) temp[T] {
 
 	// ---debug---
 	if DEBUG && fileExistInStages {
-		cfg := ""
-		switch {
-		case preload.Configuration.Config == "config.ini":
-			if helpers.FileExist(preload.Configuration.Config) {
-				cfg = "default config.ini"
-			} else {
-				cfg = "skipped default config.ini(not exist)"
-			}
-		case len(preload.Configuration.Config) > 0:
-			if helpers.FileExist(preload.Configuration.Config) {
-				// custom config file
-				cfg = filepath.Base(preload.Configuration.Config)
-			} else {
-				cfg = "skipped config file(not exist)"
+		for _, file := range baseFiles() {
+			fmt.Printf("FILE => embedded %s\n", file)
+		}
+		if len(preload.Configuration.Config) == 0 {
+			fmt.Printf("FILE => %s\n", "not any config file")
+		}
+		for _, file := range preload.Configuration.Config {
+			cfg := ""
+			switch {
+			case file == helpers.Stdin:
+				cfg = "standard input"
+			case preload.searched && file != ConfigDefault:
+				cfg = "found " + file
+			case file == ConfigDefault:
+				if helpers.Exist(fsys, file) {
+					cfg = "default " + ConfigDefault
+				} else {
+					cfg = "skipped default " + ConfigDefault + "(not exist)"
+				}
+			default:
+				if helpers.Exist(fsys, file) {
+					// custom config file
+					cfg = filepath.Base(file)
+				} else {
+					cfg = "skipped config file(not exist)"
+				}
 			}
-		default:
-			cfg = "not any config file"
+			fmt.Printf("FILE => %s\n", cfg)
 		}
-		fmt.Printf("FILE => %s\n", cfg)
 	}
 	// ---debug---
 
@@ -248,8 +520,13 @@ func get[T any](stages ...order.Stages) temp[T] {
 		Stages:                stages,
 		CustomerConfiguration: *new(T),
 		Configuration:         preload.Configuration,
+		searched:              preload.searched,
+		input:                 input,
+		flagSet:               flagSet,
+		fsys:                  fsys,
 	}).prepare(preload.Tags)
 	load.
+		requireFile().
 		fill().
 		valid()
 
@@ -259,6 +536,9 @@ func get[T any](stages ...order.Stages) temp[T] {
 	}
 	// ---debug---
 
+	if slices.Contains(stages, order.FLAG) {
+		arguments = load.args
+	}
 	return *load
 }
 
@@ -267,43 +547,144 @@ Get will initialize scan the flags(one time), environment and config-file with t
   - order.FLAG - flag
   - order.FILE - config file
   - order.ENV - environment
+  - order.DOTENV - dotenv file
+  - order.DIR - directory with file per value
+  - order.INLINE - config document inside the environment
+  - order.REMOTE - config document of HTTP(S) endpoint
+  - order.KV - key/value sources
+
+The result is memoized for every type of the struct and the stages. Use Reset to load again.
 
-Caution! flags are reserved:
-  - config
+Caution! the flag of the config file is reserved:
+  - ConfigFlag ('config' by default)
 */
 func Get[T any](stages ...order.Stages) T {
-	onceFlags.Do(
+	key := loadedKey{
+		typ:    reflect.TypeOf((*T)(nil)).Elem(),
+		stages: fmt.Sprint(stages),
+	}
+	v, _ := loaded.LoadOrStore(key, &loadedValue{})
+	value := v.(*loadedValue)
+	value.once.Do(
 		func() {
-			this = get[T](stages...)
+			value.value = required(get[T](stages...)).CustomerConfiguration
 		})
-	return this.(temp[T]).CustomerConfiguration
+	return value.value.(T)
+}
+
+// Args returns the positional arguments of the last loading with FLAG stage:
+// arguments which are not flags and everything after '--'.
+func Args() []string {
+	loadMu.Lock()
+	defer loadMu.Unlock()
+	return append([]string(nil), arguments...)
+}
+
+// Reset removes memoized results of Get for the type of the struct. Useful for tests.
+func Reset[T any]() {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	loaded.Range(func(k, _ any) bool {
+		if k.(loadedKey).typ == typ {
+			loaded.Delete(k)
+		}
+		return true
+	})
 }
 
 func (t *temp[T]) dummy() *temp[T] {
 	for _, v := range t.Tags {
-		v.DummyFlags()
+		v.DummyFlags(t.flagSet)
+	}
+	if t.flagSet == flag.CommandLine {
+		flag.Parse()
+		return t
+	}
+	// subcommand: values are already set, unknown flags are the flags of the parent
+	for _, arg := range t.input {
+		if arg == "--" {
+			break
+		}
+		switch arg {
+		case "-h", "-help", "--h", "--help":
+			t.flagSet.Usage()
+			t.errs = append(t.errs, &tags.FieldError{
+				Source: tags.SourceFlag,
+				Name:   arg,
+				Err:    flag.ErrHelp,
+			})
+			return t
+		}
 	}
-	flag.Parse()
 	return t
 }
 
 func (t *temp[T]) flag() *temp[T] {
-	for _, v := range t.Tags {
-		v.Flag()
-	}
-	return t.dummy()
+	return t.flagNoParse().dummy()
 }
 
 func (t *temp[T]) flagNoParse() *temp[T] {
+	flags := t.parseArgs()
 	for _, v := range t.Tags {
-		v.Flag()
+		v.Flag(flags)
 	}
+	tags.Positional(t.Tags, t.args)
 	return t
 }
 
+// positional binds the positional arguments without the FLAG stage. The flags are only skipped
+func (t *temp[T]) positional() *temp[T] {
+	t.parseArgs()
+	tags.Positional(t.Tags, t.args)
+	return t
+}
+
+// parseArgs returns the flags of the command line and stores the positional arguments
+func (t *temp[T]) parseArgs() []tags.Argument {
+	var flags []tags.Argument
+	if t.flagSet == flag.CommandLine {
+		flags, t.args = tags.ParseArgs(t.input, t.Tags)
+	} else {
+		// subcommand: skip the flags of the parent
+		flags, t.args = tags.ParseArgs(t.input, t.Tags, flag.CommandLine)
+	}
+	return flags
+}
+
 func (t *temp[T]) env() *temp[T] {
 	for _, v := range t.Tags {
-		v.Env()
+		v.Env(t.fsys)
+	}
+	return t
+}
+
+func (t *temp[T]) dir() *temp[T] {
+	files, err := helpers.ReadDir(t.fsys, ConfigDir)
+	if err != nil {
+		helpers.ToLog(err, fmt.Sprintf("directory '%s' error", ConfigDir))
+		t.errs = append(t.errs, &tags.FieldError{
+			Source: tags.SourceDir,
+			Name:   ConfigDir,
+			Err:    err,
+		})
+	}
+	for _, v := range t.Tags {
+		v.Dir(files)
+	}
+	return t
+}
+
+func (t *temp[T]) dotenv() *temp[T] {
+	vars, err := helpers.ReadDotEnv(t.fsys, DotEnvFile)
+	if err != nil {
+		helpers.ToLog(err, fmt.Sprintf("dotenv file '%s' error", DotEnvFile))
+		t.errs = append(t.errs, &tags.FieldError{
+			Source: tags.SourceDotEnv,
+			Name:   DotEnvFile,
+			Err:    err,
+		})
+	}
+	for _, v := range t.Tags {
+		v.DotEnv(vars, t.fsys)
 	}
 	return t
 }
@@ -311,32 +692,302 @@ func (t *temp[T]) env() *temp[T] {
 // valid check info and make some correcting
 func (t *temp[T]) valid() *temp[T] {
 	for k, v := range t.Tags {
-		field := reflect.ValueOf(&t.CustomerConfiguration).Elem().FieldByName(k)
+		field := tags.FieldByPath(reflect.ValueOf(&t.CustomerConfiguration).Elem(), k)
 		if field.CanSet() {
-			if valid := v.Valid(); valid != nil {
-				field.Set(reflect.ValueOf(valid))
-			} else {
+			valid := v.Valid(t.fsys)
+			if valid == nil {
 				field.SetZero()
+				continue
 			}
+			value, err := tags.Convert(valid, field.Type())
+			if err != nil {
+				t.errs = append(t.errs, &tags.FieldError{
+					Field:  k,
+					Source: tags.SourceValid,
+					Value:  fmt.Sprint(valid),
+					Err:    err,
+				})
+			}
+			field.Set(value)
 		}
 	}
 	return t
 }
 
-// conf get info from the configuration file
+// err collect all errors of the loading
+func (t *temp[T]) err() error {
+	errs := append(tags.Errors{}, t.errs...)
+	for _, v := range t.Tags {
+		if v.Errors != nil {
+			errs = append(errs, v.Errors()...)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	errs.Sort()
+	return errs
+}
+
+// conf get info from the configuration files. The next file overrides the previous one
 func (t *temp[T]) conf() *temp[T] {
-	confFile := ""
-	for _, f := range t.Tags["Config"].Flags {
-		if f.Value.String() != "" {
-			confFile = f.Value.String()
+	t.base()
+	if config, ok := t.Tags[configKey]; ok && config.String() != "" {
+		t.searched = config.Source() == tags.SourceDefault
+		names, _ := config.Value().([]string)
+		if t.searched {
+			t.Configuration.Config = helpers.SearchConfigFiles(t.fsys, names, searchPaths(), ConfigSearchAll)
+		} else {
+			t.Configuration.Config = helpers.ValidConfigFiles(t.fsys, names)
+		}
+		for _, file := range t.Configuration.Config {
+			tmpConfig, format, err := readConfig(t.fsys, file)
+			if err != nil {
+				helpers.ToLog(err, fmt.Sprintf("settings file '%s' error", file))
+				t.errs = append(t.errs, &tags.FieldError{
+					Source: tags.SourceFile,
+					Name:   file,
+					Err:    err,
+				})
+			}
+			for _, v := range t.Tags {
+				v.ConfigFile(tmpConfig, format)
+			}
 		}
 	}
-	if confFile != "" {
-		reflect.ValueOf(&t.Configuration).Elem().FieldByName("Config").Set(reflect.ValueOf(t.Tags["Config"].Valid()))
-		tmpConfig := helpers.SettingsFile(t.Configuration.Config)
+	if slices.Contains(t.Stages, order.RequireConfig) {
+		t.notFound()
+	}
+	return t
+}
+
+// baseFiles - names of the base config files inside BaseConfigFS
+func baseFiles() []string {
+	var ret []string
+	if BaseConfigFS == nil {
+		return ret
+	}
+	for _, pattern := range BaseConfigFiles {
+		matches, err := fs.Glob(BaseConfigFS, pattern)
+		helpers.ToLog(err, fmt.Sprintf("base config '%s' error", pattern))
+		ret = append(ret, matches...)
+	}
+	return ret
+}
+
+// base get info from the base config files. The config files of '-config' override them
+func (t *temp[T]) base() {
+	for _, file := range baseFiles() {
+		format := FORMAT
+		if format == "" {
+			format = helpers.FileFormat(file)
+		}
+		data, err := fs.ReadFile(BaseConfigFS, file)
+		var settings map[string]any
+		if err == nil {
+			settings, err = helpers.DecodeSettings(data, format)
+		}
+		if err != nil {
+			helpers.ToLog(err, fmt.Sprintf("base config '%s' error", file))
+			t.errs = append(t.errs, &tags.FieldError{
+				Source: tags.SourceFile,
+				Name:   file,
+				Err:    err,
+			})
+		}
 		for _, v := range t.Tags {
-			v.ConfigFile(tmpConfig)
+			v.ConfigFile(settings, format)
+		}
+	}
+}
+
+// readConfig - settings of the config file or the standard input ("-") and the format
+func readConfig(fsys fs.FS, file string) (map[string]any, string, error) {
+	if file != helpers.Stdin {
+		format := FORMAT
+		if format == "" {
+			format = helpers.FileFormat(file)
 		}
+		settings, err := helpers.ReadSettingsFile(fsys, file, format)
+		return settings, format, err
+	}
+	data, err := helpers.ReadStdin()
+	if err != nil {
+		return nil, "", err
+	}
+	format := FORMAT
+	if format == "" {
+		format = helpers.ContentFormat(data)
+	}
+	settings, err := helpers.DecodeSettings(data, format)
+	if err != nil {
+		err = fmt.Errorf("standard input parse error: %w", err)
+	}
+	return settings, format, err
+}
+
+// remote get info from the config document of the endpoint
+func (t *temp[T]) remote() *temp[T] {
+	if Remote.URL == "" {
+		return t
+	}
+	settings, format, err := helpers.FetchRemote(helpers.Remote{
+		URL:       Remote.URL,
+		Timeout:   Remote.Timeout,
+		Retries:   Remote.Retries,
+		Token:     os.Getenv(Remote.TokenEnv),
+		CacheFile: remoteCacheFile(),
+		Format:    FORMAT,
+		Client:    Remote.Client,
+	})
+	if err != nil {
+		helpers.ToLog(err, "remote config error")
+	}
+	// the error is not returning with the cached document
+	if err != nil && settings == nil {
+		t.errs = append(t.errs, &tags.FieldError{
+			Source: tags.SourceRemote,
+			Name:   Remote.URL,
+			Err:    err,
+		})
+	}
+	for _, v := range t.Tags {
+		v.Remote(settings, format)
 	}
 	return t
 }
+
+// remoteCacheFile - filepath of the cache of the REMOTE stage
+func remoteCacheFile() string {
+	switch Remote.CacheFile {
+	case "-":
+		return ""
+	case "":
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return ""
+		}
+		app := AppName
+		if app == "" {
+			app = helpers.AppName()
+		}
+		return filepath.Join(dir, app, "remote-config")
+	}
+	return Remote.CacheFile
+}
+
+// inline get info from the config document inside the environment
+func (t *temp[T]) inline() *temp[T] {
+	data, ok := os.LookupEnv(ConfigInlineEnv)
+	if !ok || ConfigInlineEnv == "" {
+		return t
+	}
+	format := FORMAT
+	if format == "" {
+		format = helpers.ContentFormat([]byte(data))
+	}
+	settings, err := helpers.DecodeSettings([]byte(data), format)
+	if err != nil {
+		helpers.ToLog(err, fmt.Sprintf("environment '%s' error", ConfigInlineEnv))
+		t.errs = append(t.errs, &tags.FieldError{
+			Source: tags.SourceInline,
+			Name:   ConfigInlineEnv,
+			Err:    err,
+		})
+	}
+	for _, v := range t.Tags {
+		v.Inline(settings, format)
+	}
+	return t
+}
+
+// notFound - errors of the required config files which are not found
+func (t *temp[T]) notFound() {
+	config, ok := t.Tags[configKey]
+	names, _ := config.Value().([]string)
+	switch {
+	case !ok || len(names) == 0:
+		t.errs = append(t.errs, &tags.FieldError{
+			Source: tags.SourceFile,
+			Err:    ErrConfigNotFound,
+		})
+	case t.searched:
+		if len(t.Configuration.Config) == 0 {
+			t.errs = append(t.errs, &tags.FieldError{
+				Source: tags.SourceFile,
+				Name:   strings.Join(names, ","),
+				Err:    fmt.Errorf("%w in %s", ErrConfigNotFound, strings.Join(searchPaths(), ", ")),
+			})
+		}
+	default:
+		for _, name := range names {
+			if len(helpers.ValidConfigFiles(t.fsys, []string{name})) == 0 {
+				t.errs = append(t.errs, &tags.FieldError{
+					Source: tags.SourceFile,
+					Name:   name,
+					Err:    ErrConfigNotFound,
+				})
+			}
+		}
+	}
+}
+
+// required - exit with code 1 if the config file is required and not loaded
+func required[T any](t temp[T]) temp[T] {
+	if !slices.Contains(t.Stages, order.RequireConfig) {
+		return t
+	}
+	var errs tags.Errors
+	for _, v := range t.errs {
+		if v.Source == tags.SourceFile {
+			errs = append(errs, v)
+		}
+	}
+	if len(errs) > 0 {
+		helpers.ToLog(error(errs), "required config file")
+		os.Exit(1)
+	}
+	return t
+}
+
+// requireFile - error of RequireConfig without the FILE stage: the required config file is never read
+func (t *temp[T]) requireFile() *temp[T] {
+	if slices.Contains(t.Stages, order.RequireConfig) && !slices.Contains(t.Stages, order.FILE) {
+		t.errs = append(t.errs, &tags.FieldError{
+			Source: tags.SourceFile,
+			Err:    ErrRequireConfigStage,
+		})
+	}
+	return t
+}
+
+// searchPaths - directories for the config file
+func searchPaths() []string {
+	if ConfigSearchPaths != nil {
+		return ConfigSearchPaths
+	}
+	app := AppName
+	if app == "" {
+		app = helpers.AppName()
+	}
+	paths := helpers.SearchPaths(app)
+	if ConfigSearchAll {
+		// the working directory overrides the system files
+		slices.Reverse(paths)
+	}
+	return paths
+}
+
+// printConfigFiles - printing the config files for the help
+func printConfigFiles(f *flag.FlagSet, files []string, searched bool) {
+	yellow := color.New(color.FgYellow).SprintfFunc()
+	text := "Config files: " + strings.Join(files, ", ")
+	switch {
+	case len(files) == 0 && !searched:
+		text = "Config files: not found"
+	case len(files) == 0:
+		text = "Config files: not found in " + strings.Join(searchPaths(), ", ")
+	}
+	_, err := fmt.Fprint(f.Output(), yellow("%s\n\n", text))
+	helpers.ToLog(err, "print data error")
+}
@@ -2,11 +2,19 @@ package startup_test
 
 import (
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/KusoKaihatsuSha/startup"
@@ -42,13 +50,32 @@ type TestJSON struct {
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
 func (t *TestJSON) UnmarshalText(text []byte) error {
-	type Tmp *TestJSON
-	if err := json.Unmarshal(text, Tmp(t)); err != nil {
+	type Tmp TestJSON
+	if err := json.Unmarshal(text, (*Tmp)(t)); err != nil {
 		return err
 	}
 	return nil
 }
 
+// TestPairJSON implements the json.Unmarshaler interface: the array ["p1", "p2"] is decoding to the fields
+type TestPairJSON struct {
+	P1 string
+	P2 string
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *TestPairJSON) UnmarshalJSON(data []byte) error {
+	var pair []string
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("pair must have 2 values, got %d", len(pair))
+	}
+	t.P1, t.P2 = pair[0], pair[1]
+	return nil
+}
+
 // custom email validation
 type emailValid string
 
@@ -279,7 +306,7 @@ func Example_configOrderDef() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File]
+	//	[Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -297,7 +324,7 @@ func Example_configOrderDef() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags]
+	//	[Config File] ↣ [Flags]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -305,7 +332,7 @@ func Example_configOrderDef() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File]
+	//	[Flags] ↣ [Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -313,7 +340,7 @@ func Example_configOrderDef() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments]
+	//	[Config File] ↣ [Environments]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -321,7 +348,7 @@ func Example_configOrderDef() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File]
+	//	[Environments] ↣ [Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -339,7 +366,7 @@ func Example_configOrderDef() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags] ↣ [Environments]
+	//	[Config File] ↣ [Flags] ↣ [Environments]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -347,7 +374,7 @@ func Example_configOrderDef() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File] ↣ [Environments]
+	//	[Flags] ↣ [Config File] ↣ [Environments]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -355,7 +382,7 @@ func Example_configOrderDef() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments] ↣ [Flags]
+	//	[Config File] ↣ [Environments] ↣ [Flags]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -363,7 +390,7 @@ func Example_configOrderDef() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File] ↣ [Flags]
+	//	[Environments] ↣ [Config File] ↣ [Flags]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -371,7 +398,7 @@ func Example_configOrderDef() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [Environments] ↣ [JSON File]
+	//	[Flags] ↣ [Environments] ↣ [Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -379,7 +406,7 @@ func Example_configOrderDef() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [Flags] ↣ [JSON File]
+	//	[Environments] ↣ [Flags] ↣ [Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -426,7 +453,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File]
+	//	[Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -444,7 +471,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags]
+	//	[Config File] ↣ [Flags]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -452,7 +479,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File]
+	//	[Flags] ↣ [Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -460,7 +487,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments]
+	//	[Config File] ↣ [Environments]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -468,7 +495,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File]
+	//	[Environments] ↣ [Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -486,7 +513,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags] ↣ [Environments]
+	//	[Config File] ↣ [Flags] ↣ [Environments]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -494,7 +521,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File] ↣ [Environments]
+	//	[Flags] ↣ [Config File] ↣ [Environments]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -502,7 +529,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments] ↣ [Flags]
+	//	[Config File] ↣ [Environments] ↣ [Flags]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -510,7 +537,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File] ↣ [Flags]
+	//	[Environments] ↣ [Config File] ↣ [Flags]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -518,7 +545,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [Environments] ↣ [JSON File]
+	//	[Flags] ↣ [Environments] ↣ [Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -526,7 +553,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [Flags] ↣ [JSON File]
+	//	[Environments] ↣ [Flags] ↣ [Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
@@ -587,7 +614,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File]
+	//	[Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => default config.ini
@@ -605,7 +632,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags]
+	//	[Config File] ↣ [Flags]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => default config.ini
@@ -613,7 +640,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File]
+	//	[Flags] ↣ [Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => default config.ini
@@ -621,7 +648,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments]
+	//	[Config File] ↣ [Environments]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => default config.ini
@@ -629,7 +656,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File]
+	//	[Environments] ↣ [Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => default config.ini
@@ -647,7 +674,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags] ↣ [Environments]
+	//	[Config File] ↣ [Flags] ↣ [Environments]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => default config.ini
@@ -655,7 +682,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File] ↣ [Environments]
+	//	[Flags] ↣ [Config File] ↣ [Environments]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => default config.ini
@@ -663,7 +690,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments] ↣ [Flags]
+	//	[Config File] ↣ [Environments] ↣ [Flags]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => default config.ini
@@ -671,7 +698,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File] ↣ [Flags]
+	//	[Environments] ↣ [Config File] ↣ [Flags]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => default config.ini
@@ -679,7 +706,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [Environments] ↣ [JSON File]
+	//	[Flags] ↣ [Environments] ↣ [Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => default config.ini
@@ -687,7 +714,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [Flags] ↣ [JSON File]
+	//	[Environments] ↣ [Flags] ↣ [Config File]
 	//	info about config file:	Environment 'CONFIG' with filepath not set
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => default config.ini
@@ -778,7 +805,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File]
+	//	[Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -796,7 +823,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags]
+	//	[Config File] ↣ [Flags]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -804,7 +831,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File]
+	//	[Flags] ↣ [Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -812,7 +839,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments]
+	//	[Config File] ↣ [Environments]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -820,7 +847,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File]
+	//	[Environments] ↣ [Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -838,7 +865,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags] ↣ [Environments]
+	//	[Config File] ↣ [Flags] ↣ [Environments]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -846,7 +873,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File] ↣ [Environments]
+	//	[Flags] ↣ [Config File] ↣ [Environments]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -854,7 +881,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments] ↣ [Flags]
+	//	[Config File] ↣ [Environments] ↣ [Flags]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -862,7 +889,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File] ↣ [Flags]
+	//	[Environments] ↣ [Config File] ↣ [Flags]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -870,7 +897,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [Environments] ↣ [JSON File]
+	//	[Flags] ↣ [Environments] ↣ [Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -878,7 +905,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [Flags] ↣ [JSON File]
+	//	[Environments] ↣ [Flags] ↣ [Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -957,7 +984,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File]
+	//	[Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -975,7 +1002,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags]
+	//	[Config File] ↣ [Flags]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -983,7 +1010,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File]
+	//	[Flags] ↣ [Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -991,7 +1018,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments]
+	//	[Config File] ↣ [Environments]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -999,7 +1026,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File]
+	//	[Environments] ↣ [Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -1017,7 +1044,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags] ↣ [Environments]
+	//	[Config File] ↣ [Flags] ↣ [Environments]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -1025,7 +1052,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File] ↣ [Environments]
+	//	[Flags] ↣ [Config File] ↣ [Environments]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -1033,7 +1060,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments] ↣ [Flags]
+	//	[Config File] ↣ [Environments] ↣ [Flags]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -1041,7 +1068,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File] ↣ [Flags]
+	//	[Environments] ↣ [Config File] ↣ [Flags]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -1049,7 +1076,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [Environments] ↣ [JSON File]
+	//	[Flags] ↣ [Environments] ↣ [Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -1057,7 +1084,7 @@ PRESETS
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [Flags] ↣ [JSON File]
+	//	[Environments] ↣ [Flags] ↣ [Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Get filepath from flag '-config'
 	// FILE => file-flag.confile
@@ -1110,7 +1137,7 @@ func Example_configOrigin() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File]
+	//	[Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
@@ -1128,7 +1155,7 @@ func Example_configOrigin() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags]
+	//	[Config File] ↣ [Flags]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
@@ -1136,7 +1163,7 @@ func Example_configOrigin() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File]
+	//	[Flags] ↣ [Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
@@ -1144,7 +1171,7 @@ func Example_configOrigin() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments]
+	//	[Config File] ↣ [Environments]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
@@ -1152,7 +1179,7 @@ func Example_configOrigin() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File]
+	//	[Environments] ↣ [Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
@@ -1170,7 +1197,7 @@ func Example_configOrigin() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags] ↣ [Environments]
+	//	[Config File] ↣ [Flags] ↣ [Environments]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
@@ -1178,7 +1205,7 @@ func Example_configOrigin() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File] ↣ [Environments]
+	//	[Flags] ↣ [Config File] ↣ [Environments]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
@@ -1186,7 +1213,7 @@ func Example_configOrigin() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments] ↣ [Flags]
+	//	[Config File] ↣ [Environments] ↣ [Flags]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
@@ -1194,7 +1221,7 @@ func Example_configOrigin() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File] ↣ [Flags]
+	//	[Environments] ↣ [Config File] ↣ [Flags]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
@@ -1202,7 +1229,7 @@ func Example_configOrigin() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Flags] ↣ [Environments] ↣ [JSON File]
+	//	[Flags] ↣ [Environments] ↣ [Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
@@ -1210,7 +1237,7 @@ func Example_configOrigin() {
 	//
 	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
 	// Structure filling order:
-	//	[Environments] ↣ [Flags] ↣ [JSON File]
+	//	[Environments] ↣ [Flags] ↣ [Config File]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
@@ -1222,7 +1249,7 @@ func Example_configOrigin() {
 	//
 	// NoPreloadConfig - Disable find config in other places. Only in list
 	// Structure filling order:
-	//	[JSON File] ↣ FILE => not any config file
+	//	[Config File] ↣ FILE => not any config file
 	// DATA => {def:81 email@example.com [1 2 3 4 5 6] 10 1s true 1 111 127.0.0.1 { } {default_001 default_002}}
 	//
 	// NoPreloadConfig - Disable find config in other places. Only in list
@@ -1235,27 +1262,27 @@ func Example_configOrigin() {
 	//
 	// NoPreloadConfig - Disable find config in other places. Only in list
 	// Structure filling order:
-	// [JSON File] ↣ [Flags]
+	// [Config File] ↣ [Flags]
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
 	// DATA => {def:81 flag@email.post [100 200 300] 10 1s true 1 111 127.0.0.1 { } {new_003 new_004}}
 	//
 	// NoPreloadConfig - Disable find config in other places. Only in list
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File] ↣ 	info about config file:	Flag '-config' with filepath not set
+	//	[Flags] ↣ [Config File] ↣ 	info about config file:	Flag '-config' with filepath not set
 	// FILE => not any config file
 	// DATA => {def:81 flag@email.post [100 200 300] 10 1s true 1 111 127.0.0.1 { } {new_003 new_004}}
 	//
 	// NoPreloadConfig - Disable find config in other places. Only in list
 	// Structure filling order:
-	// [JSON File] ↣ [Environments]
+	// [Config File] ↣ [Environments]
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	// FILE => test.confile
 	// DATA => {env:80 fileenv@mail.com [999] 10 1s true 1 111 127.0.0.1 { } {default_001 default_002}}
 	//
 	// NoPreloadConfig - Disable find config in other places. Only in list
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File] ↣ 	info about config file:	Get filepath from environment 'CONFIG'
+	//	[Environments] ↣ [Config File] ↣ 	info about config file:	Get filepath from environment 'CONFIG'
 	// FILE => test.confile
 	// DATA => {env:80 fileenv@mail.com [18 19 20] 10 1s true 1 111 127.0.0.1 { } {default_001 default_002}}
 	//
@@ -1269,44 +1296,1397 @@ func Example_configOrigin() {
 	//
 	// NoPreloadConfig - Disable find config in other places. Only in list
 	// Structure filling order:
-	//	[JSON File] ↣ [Flags] ↣ [Environments] ↣ 	info about config file:	Flag '-config' with filepath not set
+	//	[Config File] ↣ [Flags] ↣ [Environments] ↣ 	info about config file:	Flag '-config' with filepath not set
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	// FILE => test.confile
 	// DATA => {env:80 flag@email.post [999] 10 1s true 1 111 127.0.0.1 { } {new_003 new_004}}
 	//
 	// NoPreloadConfig - Disable find config in other places. Only in list
 	// Structure filling order:
-	//	[Flags] ↣ [JSON File] ↣ [Environments] ↣ 	info about config file:	Flag '-config' with filepath not set
+	//	[Flags] ↣ [Config File] ↣ [Environments] ↣ 	info about config file:	Flag '-config' with filepath not set
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	// FILE => test.confile
 	// DATA => {env:80 fileenv@mail.com [999] 10 1s true 1 111 127.0.0.1 { } {new_003 new_004}}
 	//
 	// NoPreloadConfig - Disable find config in other places. Only in list
 	// Structure filling order:
-	//	[JSON File] ↣ [Environments] ↣ [Flags] ↣ 	info about config file:	Get filepath from environment 'CONFIG'
+	//	[Config File] ↣ [Environments] ↣ [Flags] ↣ 	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
 	// DATA => {env:80 flag@email.post [100 200 300] 10 1s true 1 111 127.0.0.1 { } {new_003 new_004}}
 	//
 	// NoPreloadConfig - Disable find config in other places. Only in list
 	// Structure filling order:
-	//	[Environments] ↣ [JSON File] ↣ [Flags] ↣ 	info about config file:	Get filepath from environment 'CONFIG'
+	//	[Environments] ↣ [Config File] ↣ [Flags] ↣ 	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
 	// DATA => {env:80 flag@email.post [100 200 300] 10 1s true 1 111 127.0.0.1 { } {new_003 new_004}}
 	//
 	// NoPreloadConfig - Disable find config in other places. Only in list
 	// Structure filling order:
-	//	[Flags] ↣ [Environments] ↣ [JSON File] ↣ 	info about config file:	Flag '-config' with filepath not set
+	//	[Flags] ↣ [Environments] ↣ [Config File] ↣ 	info about config file:	Flag '-config' with filepath not set
 	//	info about config file:	Get filepath from environment 'CONFIG'
 	// FILE => test.confile
 	// DATA => {env:80 fileenv@mail.com [18 19 20] 10 1s true 1 111 127.0.0.1 { } {new_003 new_004}}
 	//
 	// NoPreloadConfig - Disable find config in other places. Only in list
 	// Structure filling order:
-	//	[Environments] ↣ [Flags] ↣ [JSON File] ↣ 	info about config file:	Get filepath from environment 'CONFIG'
+	//	[Environments] ↣ [Flags] ↣ [Config File] ↣ 	info about config file:	Get filepath from environment 'CONFIG'
 	//	info about config file:	Flag '-config' with filepath not set
 	// FILE => test.confile
 	// DATA => {env:80 fileenv@mail.com [18 19 20] 10 1s true 1 111 127.0.0.1 { } {new_003 new_004}}
 
 }
+
+type LoadConfiguration struct {
+	LoadPort    int           `json:"load-port"    default:"8080" flag:"load-port"    env:"LOAD_PORT"    help:"port"`
+	LoadTimeout time.Duration `json:"load-timeout" default:"1s"   flag:"load-timeout" env:"LOAD_TIMEOUT" help:"timeout"`
+	LoadName    string        `json:"load-name"    default:"name" flag:"load-name"    env:"LOAD_NAME"    help:"name"`
+}
+
+func Example_load() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	conf, err := startup.Load[LoadConfiguration](startup.ENV, startup.FLAG)
+	fmt.Println(conf, err)
+
+	err = os.Setenv("LOAD_PORT", "port")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("LOAD_PORT")
+	os.Args = append(
+		os.Args,
+		"-load-timeout="+"abc",
+		"-load-name="+"flag",
+	)
+
+	conf, err = startup.Load[LoadConfiguration](startup.ENV, startup.FLAG)
+	fmt.Println(conf)
+	var errs startup.Errors
+	if errors.As(err, &errs) {
+		for _, v := range errs {
+			fmt.Println(v.Field, v.Source, v.Name, v.Value)
+		}
+	}
+
+	// Output:
+	// {8080 1s name} <nil>
+	// {0 0s flag}
+	// LoadPort env LOAD_PORT port
+	// LoadTimeout flag -load-timeout abc
+}
+
+type GetConfiguration struct {
+	GetName string `json:"get-name" default:"get" flag:"get-name" env:"GET_NAME" help:"name"`
+}
+
+func Example_get() {
+	os.Args = defArgs
+	startup.DEBUG = false
+	defer startup.Reset[LoadConfiguration]()
+	defer startup.Reset[GetConfiguration]()
+
+	fmt.Println(startup.Get[LoadConfiguration](startup.ENV))
+	fmt.Println(startup.Get[GetConfiguration](startup.ENV))
+
+	err := os.Setenv("GET_NAME", "env")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("GET_NAME")
+
+	// memoized
+	fmt.Println(startup.Get[GetConfiguration](startup.ENV))
+	// other stages
+	fmt.Println(startup.Get[GetConfiguration](startup.ENV, startup.FLAG))
+
+	startup.Reset[GetConfiguration]()
+	fmt.Println(startup.Get[GetConfiguration](startup.ENV))
+
+	// Output:
+	// {8080 1s name}
+	// {get}
+	// {get}
+	// {env}
+	// {env}
+}
+
+type SecretConfiguration struct {
+	SecretToken string `env:"SECRET_TOKEN" default:"none" help:"token"`
+	SecretKey   string `json:"secret-key"  default:"none" help:"key"`
+}
+
+func Example_withoutFlag() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	err := os.Setenv("SECRET_TOKEN", "env-token")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("SECRET_TOKEN")
+
+	fileEnv := helpers.ValidTempFile("secret.confile")
+	defer helpers.DeleteFile(fileEnv)
+	err = os.WriteFile(fileEnv, []byte(`{"secret-key": "file-key"}`), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	err = os.Setenv("CONFIG", fileEnv)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("CONFIG")
+
+	os.Args = append(
+		os.Args,
+		"-secret-key="+"flag-key",
+	)
+
+	conf, err := startup.Load[SecretConfiguration](startup.FILE, startup.ENV)
+	fmt.Println(conf, err)
+
+	// Output:
+	// {env-token file-key} <nil>
+}
+
+type Port uint16
+
+type NumberConfiguration struct {
+	NumberInt8    int8    `default:"-8"   flag:"number-int8"    env:"NUMBER_INT8"`
+	NumberInt32   int32   `default:"32"   flag:"number-int32"   env:"NUMBER_INT32"`
+	NumberUint8   uint8   `default:"8"    flag:"number-uint8"   env:"NUMBER_UINT8"`
+	NumberFloat32 float32 `default:"0.5"  flag:"number-float32" env:"NUMBER_FLOAT32"`
+	NumberPort    Port    `default:"8080" flag:"number-port"    env:"NUMBER_PORT"`
+}
+
+func Example_numbers() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	conf, err := startup.Load[NumberConfiguration](startup.ENV, startup.FLAG)
+	fmt.Println(conf, err)
+
+	err = os.Setenv("NUMBER_PORT", "70000")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("NUMBER_PORT")
+	os.Args = append(
+		os.Args,
+		"-number-int8="+"-129",
+		"-number-uint8="+"255",
+	)
+
+	conf, err = startup.Load[NumberConfiguration](startup.ENV, startup.FLAG)
+	fmt.Println(conf)
+	fmt.Println(err)
+
+	// Output:
+	// {-8 32 8 0.5 8080} <nil>
+	// {0 32 255 0.5 0}
+	// startup: 2 configuration errors:
+	//	NumberInt8: flag '-number-int8' value '-129': strconv.ParseInt: parsing "-129": value out of range
+	//	NumberPort: env 'NUMBER_PORT' value '70000': strconv.ParseUint: parsing "70000": value out of range
+}
+
+type NativeConfiguration struct {
+	NativeObject TestNonMethodJSON `json:"native-object"`
+	NativeArray  []int             `json:"native-array"`
+	NativeMap    map[string]string `json:"native-map"`
+	NativeBig    uint64            `json:"native-big"`
+	NativeCount  int               `json:"native-count"`
+	NativeText   TestJSON          `json:"native-text"`
+	NativePair   TestPairJSON      `json:"native-pair"`
+}
+
+func Example_configNative() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	testData := `{
+		"native-object": {"param1": "p1", "param2": "p2"},
+		"native-array": [1, 2, 3],
+		"native-map": {"a": "1"},
+		"native-big": 18446744073709551615,
+		"native-count": 1000000,
+		"native-text": "{\"param1\":\"t1\"}",
+		"native-pair": ["a", "b"]
+	}`
+	file := helpers.ValidTempFile("native.confile")
+	defer helpers.DeleteFile(file)
+	err := os.WriteFile(file, []byte(testData), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	err = os.Setenv("CONFIG", file)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("CONFIG")
+
+	conf, err := startup.Load[NativeConfiguration](startup.FILE)
+	fmt.Printf("%+v %v\n", conf, err)
+
+	// Output:
+	// {NativeObject:{P1:p1 P2:p2} NativeArray:[1 2 3] NativeMap:map[a:1] NativeBig:18446744073709551615 NativeCount:1000000 NativeText:{P1:t1 P2:} NativePair:{P1:a P2:b}} <nil>
+}
+
+type DurationConfiguration struct {
+	DurationTimeout time.Duration `json:"timeout" default:"1s"`
+	DurationDelay   time.Duration `json:"delay"   default:"1s"`
+}
+
+func Example_configNativeDuration() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	startup.FS = fstest.MapFS{
+		"config.json": {Data: []byte(`{"timeout": 30, "delay": "2m"}`)},
+		"config.yaml": {Data: []byte("timeout: 1.5\ndelay: 45\n")},
+		"config.toml": {Data: []byte("timeout = 5\ndelay = \"3h\"\n")},
+	}
+	defer func() { startup.FS = nil }()
+	defer os.Unsetenv("CONFIG")
+
+	for _, file := range []string{"config.json", "config.yaml", "config.toml"} {
+		err := os.Setenv("CONFIG", file)
+		if err != nil {
+			fmt.Println(err)
+		}
+		conf, err := startup.Load[DurationConfiguration](startup.FILE)
+		fmt.Println(conf, err)
+	}
+
+	// Output:
+	// {30s 2m0s} <nil>
+	// {1.5s 45s} <nil>
+	// {5s 3h0m0s} <nil>
+}
+
+func Example_args() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	os.Args = append(
+		os.Args,
+		"-load-port", "9090",
+		"input.txt",
+		"--load-name", "flag",
+		"--load-timeout=2s",
+		"--",
+		"-load-port=1",
+		"output.txt",
+	)
+
+	conf, err := startup.Load[LoadConfiguration](startup.FLAG)
+	fmt.Println(conf, err)
+	fmt.Println(startup.Args())
+
+	// Output:
+	// {9090 2s flag} <nil>
+	// [input.txt -load-port=1 output.txt]
+}
+
+type ArgConfiguration struct {
+	ArgInput   string   `json:"input" arg:"0"    help:"input file"`
+	ArgCount   int      `json:"count" arg:"1"    help:"count" default:"1"`
+	ArgHosts   []string `json:"hosts" arg:"rest" help:"target hosts"`
+	ArgVerbose bool     `flag:"arg-verbose"      help:"verbose"`
+}
+
+func Example_positional() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	os.Args = append(
+		os.Args,
+		"in.txt",
+		"-arg-verbose",
+		"5",
+		"host1",
+		"--",
+		"-host2",
+	)
+
+	conf, err := startup.Load[ArgConfiguration](startup.FLAG)
+	fmt.Println(conf, err)
+
+	// without FLAG stage
+	os.Args = append(defArgs, "in.txt", "7")
+	conf, err = startup.Load[ArgConfiguration](startup.ENV)
+	fmt.Println(conf, err)
+
+	// missing required argument
+	os.Args = append(defArgs, "-arg-verbose")
+	conf, err = startup.Load[ArgConfiguration](startup.FLAG)
+	fmt.Println(conf, errors.Is(err, startup.ErrMissingArgument))
+	fmt.Println(err)
+
+	// Output:
+	// {in.txt 5 [host1 -host2] true} <nil>
+	// {in.txt 7 [] false} <nil>
+	// { 1 [] true} true
+	// startup: ArgInput: arg '0': positional argument is missing
+}
+
+type CommandGlobal struct {
+	CmdVerbose bool   `flag:"cmd-verbose" help:"verbose"`
+	CmdLevel   string `flag:"cmd-level"   help:"log level" default:"info"`
+}
+
+type CommandServe struct {
+	CommandGlobal
+	ServePort int `flag:"serve-port" env:"SERVE_PORT" help:"port" default:"80"`
+}
+
+type CommandMigrate struct {
+	MigrateSteps int `flag:"migrate-steps" help:"steps"`
+}
+
+func Example_command() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	startup.AddCommand[CommandServe]("serve", "Run server", startup.ENV, startup.FLAG)
+	startup.AddCommand[CommandMigrate]("migrate", "Migrate database", startup.FLAG)
+
+	os.Args = append(
+		os.Args,
+		"-cmd-level", "debug",
+		"serve",
+		"-serve-port", "8080",
+		"-cmd-verbose",
+		"-cmd-level", "warn",
+	)
+
+	global, err := startup.Load[CommandGlobal](startup.FLAG)
+	fmt.Println(global, err)
+	fmt.Println(startup.Command())
+
+	serve, err := startup.LoadCommand[CommandServe]()
+	fmt.Println(serve, err, startup.Args())
+
+	migrate, err := startup.LoadCommand[CommandMigrate]()
+	fmt.Println(migrate, err)
+
+	// options of the parent before the name are inherited
+	os.Args = append(defArgs, "-cmd-level", "debug", "serve", "-serve-port", "9090")
+	serve, err = startup.LoadCommand[CommandServe]()
+	fmt.Println(serve, err)
+
+	// value of the flag and the positional argument are not the subcommand
+	os.Args = append(defArgs, "-cmd-level", "serve", "migrate", "-migrate-steps", "2")
+	global, err = startup.Load[CommandGlobal](startup.FLAG)
+	fmt.Println(global, err, startup.Command())
+	os.Args = append(defArgs, "input.txt", "serve")
+	fmt.Printf("%q\n", startup.Command())
+
+	os.Args = append(defArgs, "serve", "-h")
+	_, err = startup.LoadCommand[CommandServe]()
+	fmt.Println(errors.Is(err, flag.ErrHelp))
+
+	// Output:
+	// {true warn} <nil>
+	// serve
+	// {{true warn} 8080} <nil> []
+	// {0} startup: command is not selected
+	// {{false debug} 9090} <nil>
+	// {false serve} <nil> migrate
+	// ""
+	// true
+}
+
+type NestedDB struct {
+	Host string `json:"host" flag:"host" env:"HOST" default:"localhost" help:"host"`
+	Port int    `json:"port" flag:"port" env:"PORT" default:"5432"      help:"port"`
+}
+
+type NestedCommon struct {
+	NestedName string `json:"nested-name" flag:"nested-name" env:"NESTED_NAME" default:"common"`
+}
+
+type NestedConfiguration struct {
+	NestedCommon
+	Database NestedDB  `json:"db"`
+	Cache    *NestedDB `prefix:"redis"`
+	Internal NestedDB  `json:"-"`
+}
+
+func Example_nested() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	testData := `{
+		"db": {"host": "file-db", "port": 1},
+		"redis": {"port": 6379},
+		"internal": {"host": "leaked"}
+	}`
+	file := helpers.ValidTempFile("nested.confile")
+	defer helpers.DeleteFile(file)
+	err := os.WriteFile(file, []byte(testData), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	err = os.Setenv("CONFIG", file)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("CONFIG")
+	err = os.Setenv("DB_PORT", "2")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("DB_PORT")
+	// json:"-" - only flags and environment
+	err = os.Setenv("INTERNAL_PORT", "3")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("INTERNAL_PORT")
+
+	os.Args = append(
+		os.Args,
+		"-redis.host", "flag-redis",
+		"-nested-name", "flag",
+	)
+
+	conf, err := startup.Load[NestedConfiguration](startup.FILE, startup.ENV, startup.FLAG)
+	fmt.Printf("%v %v %v %v %v\n", conf.NestedName, conf.Database, *conf.Cache, conf.Internal, err)
+
+	// Output:
+	// flag {file-db 2} {flag-redis 6379} {localhost 3} <nil>
+}
+
+type CollectionConfiguration struct {
+	CollectionTags   []string       `json:"tags"   default:"a,b"  flag:"collection-tag"   env:"COLLECTION_TAGS"`
+	CollectionPorts  []uint16       `json:"ports"  default:"80"   flag:"collection-port"  env:"COLLECTION_PORTS" sep:";" merge:"append"`
+	CollectionLabels map[string]int `json:"labels" default:"a=1"  flag:"collection-label" env:"COLLECTION_LABELS" merge:"append"`
+}
+
+func Example_collections() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	testData := `{
+		"ports": [8080, 8081],
+		"labels": {"b": 2}
+	}`
+	file := helpers.ValidTempFile("collection.confile")
+	defer helpers.DeleteFile(file)
+	err := os.WriteFile(file, []byte(testData), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	err = os.Setenv("CONFIG", file)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("CONFIG")
+	err = os.Setenv("COLLECTION_TAGS", "env1,env2")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("COLLECTION_TAGS")
+	err = os.Setenv("COLLECTION_PORTS", "1;2")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("COLLECTION_PORTS")
+
+	os.Args = append(
+		os.Args,
+		"-collection-tag", "flag1",
+		"-collection-tag", "flag2",
+		"-collection-label", "a=10",
+		"-collection-label", "c=3",
+	)
+
+	conf, err := startup.Load[CollectionConfiguration](startup.FILE, startup.ENV, startup.FLAG)
+	fmt.Println(conf, err)
+
+	// Output:
+	// {[flag1 flag2] [80 8080 8081 1 2] map[a:10 b:2 c:3]} <nil>
+}
+
+type YAMLDB struct {
+	Host string `json:"host" yaml:"hostname" default:"localhost"`
+	Port int    `json:"port" default:"5432"`
+}
+
+type YAMLServer struct {
+	HostName string `yaml:"host_name"`
+	Port     int    `json:"port" yaml:"server_port"`
+}
+
+type YAMLConfiguration struct {
+	YAMLName     string                `json:"name" default:"none"`
+	YAMLTTL      time.Duration         `json:"ttl" default:"1s"`
+	YAMLHosts    []string              `json:"hosts"`
+	Database     YAMLDB                `yaml:"database"`
+	YAMLServers  []YAMLServer          `json:"servers"`
+	YAMLReplicas map[string]YAMLServer `json:"replicas"`
+}
+
+func Example_yaml() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	testData := `
+name: yaml-service
+ttl: 1m
+hosts:
+  - a.local
+  - b.local
+database:
+  hostname: db.local
+  port: 6543
+servers:
+  - host_name: y1
+    server_port: 8080
+replicas:
+  main:
+    host_name: y2
+`
+	file := helpers.ValidTempFile("config.yaml")
+	defer helpers.DeleteFile(file)
+	err := os.WriteFile(file, []byte(testData), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	err = os.Setenv("CONFIG", file)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("CONFIG")
+
+	conf, err := startup.Load[YAMLConfiguration](startup.FILE)
+	fmt.Println(conf, err)
+
+	// forced format
+	forced := helpers.ValidTempFile("yaml.confile")
+	defer helpers.DeleteFile(forced)
+	err = os.WriteFile(forced, []byte("name: forced\n"), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	err = os.Setenv("CONFIG", forced)
+	if err != nil {
+		fmt.Println(err)
+	}
+	startup.FORMAT = startup.FormatYAML
+	defer func() { startup.FORMAT = "" }()
+
+	conf, err = startup.Load[YAMLConfiguration](startup.FILE)
+	fmt.Println(conf.YAMLName, err)
+
+	// Output:
+	// {yaml-service 1m0s [a.local b.local] {db.local 6543} [{y1 8080}] map[main:{y2 0}]} <nil>
+	// forced <nil>
+}
+
+type INIDB struct {
+	Host string `json:"host" default:"localhost"`
+	Port int    `json:"port" default:"5432"`
+}
+
+type INIConfiguration struct {
+	ININame  string        `json:"name" default:"none"`
+	INITTL   time.Duration `json:"ttl" default:"1s"`
+	INIHosts []string      `json:"hosts"`
+	Database INIDB         `json:"db"`
+	Replica  INIDB         `json:"replica"`
+}
+
+func Example_ini() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	testData := `
+; service
+name = "ini; service"
+ttl  = 1m # minute
+hosts: a.local,b.local
+
+[db]
+host = 'db.local'
+port = 6543
+
+[replica]
+host = replica.local
+`
+	file := helpers.ValidTempFile("config.ini")
+	defer helpers.DeleteFile(file)
+	err := os.WriteFile(file, []byte(testData), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	err = os.Setenv("CONFIG", file)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("CONFIG")
+
+	conf, err := startup.Load[INIConfiguration](startup.FILE)
+	fmt.Println(conf, err)
+
+	// Output:
+	// {ini; service 1m0s [a.local b.local] {db.local 6543} {replica.local 5432}} <nil>
+}
+
+type TOMLServer struct {
+	Name     string `json:"name"`
+	Port     int    `json:"port"`
+	HostName string `toml:"host_name"`
+}
+
+type TOMLConfiguration struct {
+	TOMLName    string       `json:"name" default:"none"  env:"TOML_NAME"`
+	TOMLTTL     string       `json:"ttl"  toml:"timeout"  default:"1s"`
+	TOMLServers []TOMLServer `json:"servers"`
+	Database    INIDB        `json:"db"  toml:"database"`
+}
+
+func Example_toml() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	testData := `
+name = "toml-service"
+timeout = "1m"
+
+[database]
+host = "db.local"
+port = 6543
+
+[[servers]]
+name = "a"
+port = 8080
+host_name = "a.local"
+
+[[servers]]
+name = "b"
+port = 8081
+`
+	file := helpers.ValidTempFile("config.toml")
+	defer helpers.DeleteFile(file)
+	err := os.WriteFile(file, []byte(testData), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	err = os.Setenv("CONFIG", file)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("CONFIG")
+	err = os.Setenv("TOML_NAME", "env-service")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("TOML_NAME")
+
+	conf, err := startup.Load[TOMLConfiguration](startup.FILE)
+	fmt.Println(conf, err)
+
+	conf, err = startup.Load[TOMLConfiguration](startup.FILE, startup.ENV)
+	fmt.Println(conf.TOMLName, err)
+
+	// Output:
+	// {toml-service 1m [{a 8080 a.local} {b 8081 }] {db.local 6543}} <nil>
+	// env-service <nil>
+}
+
+type JSONCConfiguration struct {
+	JSONCName  string   `json:"name" default:"none"`
+	JSONCHosts []string `json:"hosts"`
+}
+
+func Example_jsonc() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	testData := `{
+	// service name
+	"name": "jsonc-service",
+	/* hosts
+	   of the service */
+	"hosts": [
+		"a.local",
+		"b.local", // trailing comma
+	],
+}`
+	file := helpers.ValidTempFile("jsonc.ini")
+	defer helpers.DeleteFile(file)
+	err := os.WriteFile(file, []byte(testData), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	err = os.Setenv("CONFIG", file)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("CONFIG")
+
+	conf, err := startup.Load[JSONCConfiguration](startup.FILE)
+	fmt.Println(conf, err)
+
+	err = os.WriteFile(file, []byte("{\n\t\"name\": \"jsonc-service\"\n\t\"hosts\": []\n}"), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	_, err = startup.Load[JSONCConfiguration](startup.FILE)
+	fmt.Println(strings.ReplaceAll(err.Error(), file, "jsonc.ini"))
+
+	// Output:
+	// {jsonc-service [a.local b.local]} <nil>
+	// startup: file 'jsonc.ini': settings file 'jsonc.ini' parse error: line 3, column 2: invalid character '"' after object key:value pair
+}
+
+type DotEnvConfiguration struct {
+	DotEnvHost  string `default:"localhost" env:"DOTENV_HOST"`
+	DotEnvURL   string `env:"DOTENV_URL"`
+	DotEnvToken string `env:"DOTENV_TOKEN"`
+	DotEnvNote  string `env:"DOTENV_NOTE"`
+	DotEnvLevel string `default:"info" env:"DOTENV_LEVEL"`
+}
+
+func Example_dotenv() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	testData := `# service
+export DOTENV_HOST=db.local
+DOTENV_URL=postgres://${DOTENV_HOST}:${DOTENV_PORT:-5432} # comment
+DOTENV_TOKEN='$ecret'
+DOTENV_NOTE="line 1
+line 2"
+DOTENV_LEVEL=debug
+`
+	file := helpers.ValidTempFile("test.env")
+	defer helpers.DeleteFile(file)
+	err := os.WriteFile(file, []byte(testData), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	startup.DotEnvFile = file
+	defer func() { startup.DotEnvFile = ".env" }()
+	err = os.Setenv("DOTENV_LEVEL", "warn")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("DOTENV_LEVEL")
+
+	conf, err := startup.Load[DotEnvConfiguration](startup.DOTENV, startup.ENV)
+	fmt.Printf("%q %v\n", conf, err)
+	_, exist := os.LookupEnv("DOTENV_HOST")
+	fmt.Println(exist)
+
+	// Output:
+	// {"db.local" "postgres://db.local:5432" "$ecret" "line 1\nline 2" "warn"} <nil>
+	// false
+}
+
+type SecretFileConfiguration struct {
+	SecretFilePassword string `env:"SECRETFILE_PASSWORD"`
+	SecretFileUser     string `env:"SECRETFILE_USER"`
+	SecretFileRaw      string `env:"SECRETFILE_RAW" envfile:"false"`
+}
+
+func Example_envFile() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	file := helpers.ValidTempFile("db.secret")
+	defer helpers.DeleteFile(file)
+	err := os.WriteFile(file, []byte("pa$$word\n"), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	for k, v := range map[string]string{
+		"SECRETFILE_PASSWORD_FILE": file,
+		"SECRETFILE_USER":          "admin",
+		"SECRETFILE_RAW":           "raw",
+		"SECRETFILE_RAW_FILE":      file,
+	} {
+		err = os.Setenv(k, v)
+		if err != nil {
+			fmt.Println(err)
+		}
+		defer os.Unsetenv(k)
+	}
+
+	conf, err := startup.Load[SecretFileConfiguration](startup.ENV, startup.EnvFromFile)
+	fmt.Println(conf, err)
+
+	err = os.Setenv("SECRETFILE_USER_FILE", file)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("SECRETFILE_USER_FILE")
+
+	_, err = startup.Load[SecretFileConfiguration](startup.ENV, startup.EnvFromFile)
+	fmt.Println(err, errors.Is(err, startup.ErrEnvConflict))
+
+	// Output:
+	// {pa$$word admin raw} <nil>
+	// startup: SecretFileUser: env 'SECRETFILE_USER': both environment and its _FILE are set: 'SECRETFILE_USER' and 'SECRETFILE_USER_FILE' true
+}
+
+type DirConfiguration struct {
+	DirHost  string `json:"host" default:"localhost"`
+	DirPort  int    `default:"80" env:"DIR_PORT"`
+	Database INIDB  `json:"db"`
+}
+
+func Example_dir() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	// Kubernetes layout: key -> ..data/key -> ..2024_01_01/key
+	dir, err := os.MkdirTemp("", "configmap")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.RemoveAll(dir)
+	data := filepath.Join(dir, "..2024_01_01")
+	err = os.Mkdir(data, 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	for k, v := range map[string]string{
+		"host":     "app.local\n",
+		"DIR_PORT": "8080",
+		"db.host":  "db.local",
+	} {
+		err = os.WriteFile(filepath.Join(data, k), []byte(v), 0755)
+		if err != nil {
+			fmt.Println(err)
+		}
+		err = os.Symlink(filepath.Join("..data", k), filepath.Join(dir, k))
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+	err = os.Symlink("..2024_01_01", filepath.Join(dir, "..data"))
+	if err != nil {
+		fmt.Println(err)
+	}
+	startup.ConfigDir = dir
+	defer func() { startup.ConfigDir = "/etc/config" }()
+
+	conf, err := startup.Load[DirConfiguration](startup.DIR)
+	fmt.Println(conf, err)
+
+	// Output:
+	// {app.local 8080 {db.local 5432}} <nil>
+}
+
+type LayeredConfiguration struct {
+	LayeredName  string   `json:"name" default:"none"`
+	LayeredLevel string   `json:"level" default:"info"`
+	LayeredPort  int      `json:"port" default:"80"`
+	LayeredTags  []string `json:"tags" merge:"append"`
+}
+
+func Example_layeredConfig() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	dir, err := os.MkdirTemp("", "layered")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.RemoveAll(dir)
+	confD := filepath.Join(dir, "conf.d")
+	err = os.Mkdir(confD, 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	for k, v := range map[string]string{
+		filepath.Join(dir, "base.json"):      `{"name": "base", "level": "debug", "port": 8080, "tags": ["base"]}`,
+		filepath.Join(dir, "local.yaml"):     "level: warn\ntags: [local]\n",
+		filepath.Join(confD, "10-port.json"): `{"port": 9090}`,
+		filepath.Join(confD, "20-name.json"): `{"name": "conf.d"}`,
+		filepath.Join(confD, "README.md"):    `skipped`,
+	} {
+		err = os.WriteFile(k, []byte(v), 0755)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	os.Args = append(
+		os.Args,
+		"-config", filepath.Join(dir, "base.json"),
+		"-config", filepath.Join(dir, "local.yaml"),
+	)
+	conf, err := startup.Load[LayeredConfiguration](startup.FILE, startup.FLAG)
+	fmt.Println(conf, err)
+
+	os.Args = append(defArgs, "-config="+filepath.Join(dir, "base.json")+","+confD)
+	conf, err = startup.Load[LayeredConfiguration](startup.FILE, startup.FLAG)
+	fmt.Println(conf, err)
+
+	os.Args = append(defArgs, "-config="+filepath.Join(confD, "*.json"))
+	conf, err = startup.Load[LayeredConfiguration](startup.FILE, startup.FLAG)
+	fmt.Println(conf, err)
+
+	os.Args = append(defArgs, "-config", filepath.Join(dir, "base.json"), "-config", filepath.Join(dir, "local.yaml"))
+	startup.DEBUG = true
+	_ = startup.GetForce[LayeredConfiguration](startup.FILE, startup.FLAG)
+	startup.DEBUG = false
+
+	// Output:
+	// {base warn 8080 [base local]} <nil>
+	// {conf.d debug 9090 [base]} <nil>
+	// {conf.d info 9090 []} <nil>
+	// PreloadConfigEnvThenFlag/Default - Preload find config in Env then Flag
+	// Structure filling order:
+	//	[Config File] ↣ [Flags]
+	//	info about config file:	Environment 'CONFIG' with filepath not set
+	//	info about config file:	Get filepath from flag '-config'
+	// FILE => base.json
+	// FILE => local.yaml
+	// DATA => {base warn 8080 [base local]}
+}
+
+type SearchConfiguration struct {
+	SearchName  string `json:"name" default:"none"`
+	SearchLevel string `json:"level" default:"info"`
+}
+
+func Example_searchPaths() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	dir, err := os.MkdirTemp("", "search")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.RemoveAll(dir)
+	etc := filepath.Join(dir, "etc", "app")
+	home := filepath.Join(dir, "home", ".config", "app")
+	for k, v := range map[string]string{
+		etc:  `{"name": "etc", "level": "debug"}`,
+		home: `{"name": "home"}`,
+	} {
+		err = os.MkdirAll(k, 0755)
+		if err != nil {
+			fmt.Println(err)
+		}
+		err = os.WriteFile(filepath.Join(k, "config.ini"), []byte(v), 0755)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+	startup.ConfigSearchPaths = []string{".", filepath.Join(dir, "missing"), home, etc}
+	defer func() { startup.ConfigSearchPaths = nil }()
+
+	conf, err := startup.Load[SearchConfiguration](startup.FILE)
+	fmt.Println(conf, err)
+
+	// merge from /etc to home
+	startup.ConfigSearchPaths = []string{etc, home}
+	startup.ConfigSearchAll = true
+	defer func() { startup.ConfigSearchAll = false }()
+
+	conf, err = startup.Load[SearchConfiguration](startup.FILE)
+	fmt.Println(conf, err)
+
+	// Output:
+	// {home info} <nil>
+	// {home debug} <nil>
+}
+
+type RenamedConfiguration struct {
+	RenamedName string   `json:"name" default:"none"`
+	Config      []string `default:"own" flag:"config" env:"CONFIG"`
+}
+
+func Example_configRenamed() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	file := helpers.ValidTempFile("renamed.confile")
+	defer helpers.DeleteFile(file)
+	err := os.WriteFile(file, []byte(`{"name": "renamed"}`), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	startup.ConfigFlag, startup.ConfigEnv, startup.ConfigDefault = "app-config", "APP_CONFIG", "app.json"
+	defer func() {
+		startup.ConfigFlag, startup.ConfigEnv, startup.ConfigDefault = "config", "CONFIG", "config.ini"
+	}()
+
+	os.Args = append(os.Args, "-app-config="+file, "-config=a,b")
+	conf, err := startup.Load[RenamedConfiguration](startup.FILE, startup.FLAG)
+	fmt.Println(conf, err)
+
+	// disabled config file
+	startup.ConfigFlag, startup.ConfigEnv, startup.ConfigDefault = "", "", ""
+	conf, err = startup.Load[RenamedConfiguration](startup.FILE, startup.FLAG)
+	fmt.Println(conf, err)
+
+	// Output:
+	// {renamed [a b]} <nil>
+	// {none [a b]} <nil>
+}
+
+type RequiredConfiguration struct {
+	RequiredName string `json:"name" default:"none"`
+}
+
+func Example_requireConfig() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	os.Args = append(os.Args, "-config=missing.json")
+	_, err := startup.Load[RequiredConfiguration](startup.FILE, startup.FLAG, startup.RequireConfig)
+	fmt.Println(err, errors.Is(err, startup.ErrConfigNotFound))
+
+	// the file is never read without the FILE stage
+	_, err = startup.Load[RequiredConfiguration](startup.FLAG, startup.RequireConfig)
+	fmt.Println(errors.Is(err, startup.ErrRequireConfigStage))
+
+	// not required
+	conf, err := startup.Load[RequiredConfiguration](startup.FILE, startup.FLAG)
+	fmt.Println(conf, err)
+
+	file := helpers.ValidTempFile("required.json")
+	defer helpers.DeleteFile(file)
+	err = os.WriteFile(file, []byte(`{"name": "required",}`), 0755)
+	if err != nil {
+		fmt.Println(err)
+	}
+	os.Args = append(defArgs, "-config="+file)
+	conf, err = startup.Load[RequiredConfiguration](startup.FILE, startup.FLAG, startup.RequireConfig)
+	fmt.Println(conf, err)
+
+	// Output:
+	// startup: file 'missing.json': config file is not found true
+	// true
+	// {none} <nil>
+	// {required} <nil>
+}
+
+// TestRequireConfigExit - GetForce exits with code 1 if the required config file is not found
+func TestRequireConfigExit(t *testing.T) {
+	if os.Getenv("STARTUP_TEST_REQUIRE_EXIT") == "1" {
+		os.Args = append(defArgs, "-config=missing.json")
+		startup.GetForce[RequiredConfiguration](startup.FILE, startup.FLAG, startup.RequireConfig)
+		return
+	}
+	cmd := exec.Command(defArgs[0], "-test.run=^TestRequireConfigExit$")
+	cmd.Env = append(os.Environ(), "STARTUP_TEST_REQUIRE_EXIT=1")
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("exit error = %v, want exit status 1", err)
+	}
+}
+
+type InlineConfiguration struct {
+	InlineName  string   `json:"name" default:"none"`
+	InlineHosts []string `json:"hosts"`
+	Database    INIDB    `json:"db"`
+}
+
+func Example_inline() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	err := os.Setenv("CONFIG_CONTENT", `{"name": "inline-json", "db": {"host": "db.local"}}`)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("CONFIG_CONTENT")
+	conf, err := startup.Load[InlineConfiguration](startup.INLINE)
+	fmt.Println(conf, err)
+
+	startup.ConfigInlineEnv = "APP_CONFIG_YAML"
+	defer func() { startup.ConfigInlineEnv = "CONFIG_CONTENT" }()
+	err = os.Setenv("APP_CONFIG_YAML", "name: inline-yaml\nhosts: [a, b]\n")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("APP_CONFIG_YAML")
+	conf, err = startup.Load[InlineConfiguration](startup.INLINE)
+	fmt.Println(conf, err)
+
+	// standard input
+	r, w, err := os.Pipe()
+	if err != nil {
+		fmt.Println(err)
+	}
+	_, err = w.WriteString(`{"name": "stdin", "hosts": ["c"]}`)
+	if err != nil {
+		fmt.Println(err)
+	}
+	w.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+
+	os.Args = append(os.Args, "-config=-")
+	conf, err = startup.Load[InlineConfiguration](startup.FILE, startup.FLAG)
+	fmt.Println(conf, err)
+
+	// Output:
+	// {inline-json [] {db.local 5432}} <nil>
+	// {inline-yaml [a b] {localhost 5432}} <nil>
+	// {stdin [c] {localhost 5432}} <nil>
+}
+
+type FSConfiguration struct {
+	FSName   string `json:"name" default:"none"`
+	FSLevel  string `json:"level" default:"info"`
+	FSSecret string `env:"FS_SECRET"`
+	FSExtra  string `env:"FS_EXTRA" valid:"default_configuration_file"`
+	Database INIDB  `json:"db"`
+}
+
+func Example_fs() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	startup.BaseConfigFS = fstest.MapFS{
+		"defaults/base.yaml": {Data: []byte("name: base\nlevel: debug\ndb:\n  host: db.base\n")},
+	}
+	startup.BaseConfigFiles = []string{"defaults/*.yaml"}
+	startup.FS = fstest.MapFS{
+		"etc/app.json":  {Data: []byte(`{"name": "app", "db": {"port": 6543}}`)},
+		"run/secret/fs": {Data: []byte("s3cret\n")},
+	}
+	defer func() {
+		startup.BaseConfigFS, startup.BaseConfigFiles, startup.FS = nil, nil, nil
+	}()
+	err := os.Setenv("FS_SECRET_FILE", "run/secret/fs")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("FS_SECRET_FILE")
+	// the validation checks the file inside startup.FS
+	err = os.Setenv("FS_EXTRA", "etc/app.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("FS_EXTRA")
+
+	conf, err := startup.Load[FSConfiguration](startup.FILE, startup.ENV, startup.EnvFromFile)
+	fmt.Println(conf, err)
+
+	os.Args = append(os.Args, "-config=etc/app.json")
+	conf, err = startup.Load[FSConfiguration](startup.FILE, startup.ENV, startup.FLAG, startup.EnvFromFile)
+	fmt.Println(conf, err)
+
+	// Output:
+	// {base debug s3cret etc/app.json {db.base 5432}} <nil>
+	// {app debug s3cret etc/app.json {db.base 6543}} <nil>
+}
+
+type RemoteConfiguration struct {
+	RemoteName string `json:"name" default:"none"`
+	RemotePort int    `json:"port" default:"80"`
+}
+
+func Example_remote() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %q", r.Header.Get("Authorization"), r.Header.Get("If-None-Match")))
+		switch {
+		case len(requests) == 1:
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.Header.Get("If-None-Match") == `"v1"`:
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Content-Type", "application/yaml")
+			_, _ = w.Write([]byte("name: remote\nport: 8080\n"))
+		}
+	}))
+	defer server.Close()
+
+	dir, err := os.MkdirTemp("", "remote")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.RemoveAll(dir)
+	remote := startup.Remote
+	startup.Remote = startup.RemoteOptions{
+		URL:       server.URL + "/config",
+		Timeout:   time.Second,
+		Retries:   1,
+		TokenEnv:  "REMOTE_TOKEN",
+		CacheFile: filepath.Join(dir, "cache"),
+	}
+	defer func() { startup.Remote = remote }()
+	err = os.Setenv("REMOTE_TOKEN", "t0ken")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.Unsetenv("REMOTE_TOKEN")
+
+	conf, err := startup.Load[RemoteConfiguration](startup.REMOTE)
+	fmt.Println(conf, err)
+
+	// not modified
+	conf, err = startup.Load[RemoteConfiguration](startup.REMOTE)
+	fmt.Println(conf, err)
+
+	// last-known-good cache
+	server.Close()
+	conf, err = startup.Load[RemoteConfiguration](startup.REMOTE)
+	fmt.Println(conf, err)
+
+	fmt.Println(strings.Join(requests, "\n"))
+
+	// Output:
+	// {remote 8080} <nil>
+	// {remote 8080} <nil>
+	// {remote 8080} <nil>
+	// Bearer t0ken ""
+	// Bearer t0ken ""
+	// Bearer t0ken "\"v1\""
+}
+
+func Example_remoteBrokenDocument() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	body := "name: remote\nport: 8080\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	dir, err := os.MkdirTemp("", "remote")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.RemoveAll(dir)
+	remote := startup.Remote
+	startup.Remote = startup.RemoteOptions{
+		URL:       server.URL + "/config",
+		CacheFile: filepath.Join(dir, "cache"),
+	}
+	defer func() { startup.Remote = remote }()
+
+	conf, err := startup.Load[RemoteConfiguration](startup.REMOTE)
+	fmt.Println(conf, err)
+
+	// broken document is not cached, the last-known-good cache is using
+	body = "name: [broken\n"
+	conf, err = startup.Load[RemoteConfiguration](startup.REMOTE)
+	fmt.Println(conf, err)
+
+	cached, err := os.ReadFile(filepath.Join(dir, "cache"))
+	fmt.Printf("%q %v\n", cached, err)
+
+	// Output:
+	// {remote 8080} <nil>
+	// {remote 8080} <nil>
+	// "name: remote\nport: 8080\n" <nil>
+}
+
+type KVConfiguration struct {
+	KVName  string `json:"name" default:"none"`
+	KVToken string `json:"token" env:"KV_TOKEN"`
+	KVDB    struct {
+		Host string `json:"host" default:"localhost"`
+		Port int    `json:"port" default:"5432"`
+	} `json:"db"`
+}
+
+func Example_kv() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("X-Consul-Token"))
+		switch r.URL.Path {
+		case "/v1/kv/app/":
+			_ = json.NewEncoder(w).Encode([]map[string]any{
+				{"Key": "app/", "Value": nil},
+				{"Key": "app/db/port", "Value": []byte("6543")},
+				{"Key": "app/KV_TOKEN", "Value": []byte("s3cret")},
+			})
+		case "/v1/kv/broken/":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	defer startup.ResetSources()
+
+	startup.AddSource("app/", startup.MemorySource{
+		"app/name":    "memory",
+		"app/db/host": "db.local",
+		"app/db/port": "1234",
+		"other/name":  "other",
+	})
+	startup.AddSource("app/", startup.ConsulSource{Address: server.URL, Token: "acl"})
+	startup.AddSource("missing/", startup.ConsulSource{Address: server.URL})
+	conf, err := startup.Load[KVConfiguration](startup.KV)
+	fmt.Println(conf, err)
+
+	startup.AddSource("broken/", startup.ConsulSource{Address: server.URL})
+	conf, err = startup.Load[KVConfiguration](startup.KV)
+	fmt.Println(conf)
+	var errs startup.Errors
+	if errors.As(err, &errs) {
+		for _, v := range errs {
+			fmt.Println(v.Source, v.Name, strings.HasSuffix(v.Err.Error(), "status 500 Internal Server Error"))
+		}
+	}
+	fmt.Printf("%q\n", tokens)
+
+	// Output:
+	// {memory s3cret {db.local 6543}} <nil>
+	// {memory s3cret {db.local 6543}}
+	// kv broken/ true
+	// ["acl" "" "acl" "" ""]
+}
+
+// countingSource - key/value source counting the calls of List
+type countingSource struct {
+	startup.MemorySource
+	calls *int
+}
+
+func (c countingSource) List(prefix string) (map[string]string, error) {
+	*c.calls++
+	return c.MemorySource.List(prefix)
+}
+
+func Example_noPreloadConfigNetwork() {
+	os.Args = defArgs
+	startup.DEBUG = false
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"name": "remote"}`))
+	}))
+	defer server.Close()
+	remote := startup.Remote
+	startup.Remote = startup.RemoteOptions{URL: server.URL, CacheFile: "-"}
+	defer func() { startup.Remote = remote }()
+
+	calls := 0
+	startup.AddSource("app/", countingSource{startup.MemorySource{"app/port": "8080"}, &calls})
+	defer startup.ResetSources()
+
+	conf, err := startup.Load[RemoteConfiguration](startup.REMOTE, startup.KV, startup.NoPreloadConfig)
+	fmt.Println(conf, err, requests, calls)
+
+	// Output:
+	// {remote 8080} <nil> 1 1
+}